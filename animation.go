@@ -0,0 +1,102 @@
+package main
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// animationSlot identifies one of the animations that a table can run.
+// Starting an animation in a slot cancels any that is already running there.
+type animationSlot int
+
+const (
+	animSnapBack animationSlot = iota
+	animCelebration
+)
+
+// runningAnimation is an animation started by the animator along with the function to call when it ends
+type runningAnimation struct {
+	anim  *fyne.Animation
+	end   func(completed bool)
+	ended bool
+}
+
+// animator schedules the animations of a table using fyne.Animation so that they are drawn in step with the display.
+// Animations can be cancelled, so a new game can stop anything still moving over the old one.
+type animator struct {
+	running map[animationSlot]*runningAnimation
+
+	run func(*fyne.Animation) // starts each animation, tests may replace this to tick them manually
+}
+
+// start runs tick for the duration in the slot.
+// The end function is called once, with completed set to false if the animation was cancelled.
+func (a *animator) start(slot animationSlot, d time.Duration, curve fyne.AnimationCurve,
+	tick func(float32), end func(completed bool)) {
+	a.cancel(slot)
+	if a.running == nil {
+		a.running = make(map[animationSlot]*runningAnimation)
+	}
+
+	r := &runningAnimation{end: end}
+	r.anim = fyne.NewAnimation(d, func(done float32) {
+		if r.ended {
+			return
+		}
+
+		tick(done)
+		if done >= 1 {
+			a.stop(slot, r, true)
+		}
+	})
+	r.anim.Curve = curve
+	a.running[slot] = r
+	if a.run != nil {
+		a.run(r.anim)
+	} else {
+		r.anim.Start()
+	}
+}
+
+// isRunning returns true if there is an animation running in the slot
+func (a *animator) isRunning(slot animationSlot) bool {
+	_, ok := a.running[slot]
+	return ok
+}
+
+// finish ends the animation in the slot early, as though it had completed
+func (a *animator) finish(slot animationSlot) {
+	if r, ok := a.running[slot]; ok {
+		a.stop(slot, r, true)
+	}
+}
+
+// cancel stops the animation in the slot, if there is one
+func (a *animator) cancel(slot animationSlot) {
+	if r, ok := a.running[slot]; ok {
+		a.stop(slot, r, false)
+	}
+}
+
+// cancelAll stops every running animation
+func (a *animator) cancelAll() {
+	for slot, r := range a.running {
+		a.stop(slot, r, false)
+	}
+}
+
+func (a *animator) stop(slot animationSlot, r *runningAnimation, completed bool) {
+	if r.ended {
+		return
+	}
+	r.ended = true
+	r.anim.Stop()
+	if a.running[slot] == r {
+		delete(a.running, slot)
+	}
+
+	if r.end != nil {
+		r.end(completed)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// newManualAnimator returns an animator whose animations only advance when ticked by the test
+func newManualAnimator() *animator {
+	return &animator{run: func(*fyne.Animation) {}}
+}
+
+func TestAnimator_Cancel(t *testing.T) {
+	anims := newManualAnimator()
+	var ends []bool
+	anims.start(animSnapBack, time.Minute, fyne.AnimationLinear, func(float32) {}, func(completed bool) {
+		ends = append(ends, completed)
+	})
+	assert.True(t, anims.isRunning(animSnapBack))
+	assert.False(t, anims.isRunning(animCelebration))
+
+	anims.cancel(animSnapBack)
+	assert.False(t, anims.isRunning(animSnapBack))
+	assert.Equal(t, []bool{false}, ends)
+
+	anims.cancel(animSnapBack)
+	assert.Equal(t, []bool{false}, ends)
+}
+
+func TestAnimator_Finish(t *testing.T) {
+	anims := newManualAnimator()
+	var ends []bool
+	anims.start(animCelebration, time.Minute, fyne.AnimationLinear, func(float32) {}, func(completed bool) {
+		ends = append(ends, completed)
+	})
+	anims.finish(animCelebration)
+	assert.False(t, anims.isRunning(animCelebration))
+	assert.Equal(t, []bool{true}, ends)
+}
+
+func TestAnimator_StartReplaces(t *testing.T) {
+	anims := newManualAnimator()
+	var first, second []bool
+	anims.start(animSnapBack, time.Minute, fyne.AnimationLinear, func(float32) {}, func(completed bool) {
+		first = append(first, completed)
+	})
+	anims.start(animSnapBack, time.Minute, fyne.AnimationLinear, func(float32) {}, func(completed bool) {
+		second = append(second, completed)
+	})
+	assert.Equal(t, []bool{false}, first)
+	assert.Nil(t, second)
+
+	anims.cancelAll()
+	assert.Equal(t, []bool{false}, second)
+	assert.False(t, anims.isRunning(animSnapBack))
+}
+
+func TestAnimator_Tick(t *testing.T) {
+	anims := newManualAnimator()
+	var ticks []float32
+	var ends []bool
+	anims.start(animSnapBack, time.Minute, fyne.AnimationLinear, func(done float32) {
+		ticks = append(ticks, done)
+	}, func(completed bool) {
+		ends = append(ends, completed)
+	})
+
+	anim := anims.running[animSnapBack].anim
+	anim.Tick(0.5)
+	assert.Nil(t, ends)
+	anim.Tick(1)
+	assert.Equal(t, []bool{true}, ends)
+	assert.False(t, anims.isRunning(animSnapBack))
+
+	anim.Tick(1) // a late frame after the animation ended is ignored
+	assert.Equal(t, []float32{0.5, 1}, ticks)
+	assert.Equal(t, []bool{true}, ends)
+}
+
+func TestTable_NewGameCancelsCelebration(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	table := newWonTable()
+	table.animations.run = func(*fyne.Animation) {}
+
+	finished := false
+	table.celebrate(func() {
+		finished = true
+	})
+	assert.True(t, table.animations.isRunning(animCelebration))
+
+	table.setGame(NewGame(), "")
+	assert.False(t, table.animations.isRunning(animCelebration))
+	assert.False(t, finished)
+}
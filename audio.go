@@ -0,0 +1,60 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+
+	"fyne.io/fyne/v2"
+)
+
+// commandBackend plays sounds by writing them to a temporary WAV file and running the system audio player
+type commandBackend struct {
+	command string
+	args    []string
+}
+
+// newAudioBackend returns a backend that uses the audio player found on this system,
+// or a silent backend if there is none (such as on mobile or a headless machine).
+func newAudioBackend() AudioBackend {
+	var candidates [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"afplay"}}
+	case "windows":
+		candidates = [][]string{{"powershell", "-NoProfile", "-Command",
+			"(New-Object Media.SoundPlayer $args[0]).PlaySync()"}}
+	case "linux", "freebsd", "openbsd", "netbsd":
+		candidates = [][]string{{"paplay"}, {"aplay", "-q"}}
+	}
+
+	for _, c := range candidates {
+		if path, err := exec.LookPath(c[0]); err == nil {
+			return &commandBackend{command: path, args: c[1:]}
+		}
+	}
+	return silentBackend{}
+}
+
+func (b *commandBackend) Play(s Sound, volume float64) {
+	data := soundWAV(s, volume)
+	go func() {
+		f, err := os.CreateTemp("", "solitaire-*.wav")
+		if err != nil {
+			fyne.LogError("Unable to create sound file", err)
+			return
+		}
+		defer os.Remove(f.Name())
+
+		_, err = f.Write(data)
+		_ = f.Close()
+		if err != nil {
+			fyne.LogError("Unable to write sound file", err)
+			return
+		}
+
+		if err := exec.Command(b.command, append(b.args, f.Name())...).Run(); err != nil {
+			fyne.LogError("Unable to play sound", err)
+		}
+	}()
+}
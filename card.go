@@ -1,7 +1,9 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"strings"
 
 	"fyne.io/fyne/v2"
 
@@ -39,6 +41,32 @@ const (
 	ValueKing = 13
 )
 
+var (
+	valueNotation = []string{"A", "2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K"}
+	valueNames    = []string{"Ace", "Two", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine", "Ten",
+		"Jack", "Queen", "King"}
+
+	suitNotation = []string{"C", "D", "H", "S"}
+	suitSymbols  = []string{"♣", "♦", "♥", "♠"}
+	suitNames    = []string{"Clubs", "Diamonds", "Hearts", "Spades"}
+)
+
+// String returns the name of the suit, such as "Hearts"
+func (s Suit) String() string {
+	if s < SuitClubs || s > SuitSpades {
+		return fmt.Sprintf("Suit(%d)", int(s))
+	}
+	return suitNames[s]
+}
+
+// Symbol returns the symbol that is printed on cards of the suit, such as "♥"
+func (s Suit) Symbol() string {
+	if s < SuitClubs || s > SuitSpades {
+		return "?"
+	}
+	return suitSymbols[s]
+}
+
 // Card is a single playing card, it has a face value and a suit associated with it.
 type Card struct {
 	Value int
@@ -71,6 +99,87 @@ func (c *Card) Color() SuitColor {
 	return SuitColorRed
 }
 
+// String returns the value and suit symbol of the card, such as "10♥" or "Q♠"
+func (c *Card) String() string {
+	if !c.valid() {
+		return fmt.Sprintf("Card(%d, %d)", c.Value, int(c.Suit))
+	}
+	return valueNotation[c.Value-1] + c.Suit.Symbol()
+}
+
+// Notation returns the value and suit letter of the card, such as "10H" or "QS".
+// This form uses only ASCII characters so it is used when saving or sharing cards.
+func (c *Card) Notation() string {
+	if !c.valid() {
+		return fmt.Sprintf("Card(%d, %d)", c.Value, int(c.Suit))
+	}
+	return valueNotation[c.Value-1] + suitNotation[c.Suit]
+}
+
+// Name returns the full name of the card, such as "Queen of Spades", that can be read out to the player
+func (c *Card) Name() string {
+	if !c.valid() {
+		return fmt.Sprintf("Card(%d, %d)", c.Value, int(c.Suit))
+	}
+	return valueNames[c.Value-1] + " of " + c.Suit.String()
+}
+
+// MarshalText encodes the card in its notation, with a '#' prefix if it is face down
+func (c *Card) MarshalText() ([]byte, error) {
+	if !c.valid() {
+		return nil, fmt.Errorf("invalid card value %d or suit %d", c.Value, int(c.Suit))
+	}
+	if !c.FaceUp {
+		return []byte(faceDownMarker + c.Notation()), nil
+	}
+	return []byte(c.Notation()), nil
+}
+
+// UnmarshalText sets the card from text created by MarshalText
+func (c *Card) UnmarshalText(text []byte) error {
+	s := string(text)
+	faceDown := strings.HasPrefix(s, faceDownMarker)
+	parsed, err := ParseCard(strings.TrimPrefix(s, faceDownMarker))
+	if err != nil {
+		return err
+	}
+
+	*c = *parsed
+	c.FaceUp = !faceDown
+	return nil
+}
+
+func (c *Card) valid() bool {
+	return c.Value >= 1 && c.Value <= ValueKing && c.Suit >= SuitClubs && c.Suit <= SuitSpades
+}
+
+// ParseCard returns the face down card for a value followed by a suit letter or symbol, such as "10H", "qs" or "A♣"
+func ParseCard(s string) (*Card, error) {
+	upper := strings.ToUpper(strings.TrimSpace(s))
+	suit := -1
+	for i := range suitNotation {
+		if strings.HasSuffix(upper, suitNotation[i]) {
+			upper, suit = strings.TrimSuffix(upper, suitNotation[i]), i
+			break
+		}
+		if strings.HasSuffix(upper, suitSymbols[i]) {
+			upper, suit = strings.TrimSuffix(upper, suitSymbols[i]), i
+			break
+		}
+	}
+
+	value := -1
+	for i, name := range valueNotation {
+		if upper == name {
+			value = i + 1
+		}
+	}
+	if value < 0 || suit < 0 {
+		return nil, fmt.Errorf("invalid card %q", s)
+	}
+	return &Card{Value: value, Suit: Suit(suit)}, nil
+}
+
 // NewCard returns a new card instance with the specified suit and value (1 based for Ace, 2 is 2 and so on).
 func NewCard(value int, suit Suit) *Card {
 	if value < 1 || value > 13 {
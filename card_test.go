@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,3 +27,63 @@ func TestCard_TurnFaceDown(t *testing.T) {
 
 	assert.False(t, card.FaceUp)
 }
+
+func TestCard_String(t *testing.T) {
+	card := NewCard(10, SuitHearts)
+	assert.Equal(t, "10♥", card.String())
+	assert.Equal(t, "10H", card.Notation())
+	assert.Equal(t, "Ten of Hearts", card.Name())
+
+	card = NewCard(ValueQueen, SuitSpades)
+	assert.Equal(t, "Q♠", card.String())
+	assert.Equal(t, "QS", card.Notation())
+	assert.Equal(t, "Queen of Spades", card.Name())
+
+	assert.Equal(t, "Card(0, 1)", (&Card{Suit: SuitDiamonds}).String())
+}
+
+func TestSuit_String(t *testing.T) {
+	assert.Equal(t, "Clubs", SuitClubs.String())
+	assert.Equal(t, "♦", SuitDiamonds.Symbol())
+	assert.Equal(t, "Suit(7)", Suit(7).String())
+}
+
+func TestParseCard(t *testing.T) {
+	for text, want := range map[string]*Card{
+		"10h": {Value: 10, Suit: SuitHearts},
+		"QS":  {Value: ValueQueen, Suit: SuitSpades},
+		"A♣":  {Value: 1, Suit: SuitClubs},
+		"k♦":  {Value: ValueKing, Suit: SuitDiamonds},
+	} {
+		c, err := ParseCard(text)
+		assert.NoError(t, err)
+		assert.Equal(t, want, c, text)
+	}
+
+	for _, c := range NewSortedDeck().Cards {
+		parsed, err := ParseCard(c.String())
+		assert.NoError(t, err)
+		assert.Equal(t, c, parsed)
+	}
+
+	for _, bad := range []string{"", "A", "11C", "AX", "0S", "AS1", "♠"} {
+		_, err := ParseCard(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestCard_MarshalJSON(t *testing.T) {
+	up := NewCard(ValueJack, SuitDiamonds)
+	up.TurnFaceUp()
+	data, err := json.Marshal([]*Card{up, NewCard(2, SuitClubs)})
+	assert.NoError(t, err)
+	assert.Equal(t, `["JD","#2C"]`, string(data))
+
+	var cards []*Card
+	assert.NoError(t, json.Unmarshal(data, &cards))
+	assert.Equal(t, []*Card{up, NewCard(2, SuitClubs)}, cards)
+
+	assert.Error(t, json.Unmarshal([]byte(`["1C"]`), &cards))
+	_, err = json.Marshal(&Card{Value: 14})
+	assert.Error(t, err)
+}
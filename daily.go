@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	dailyDateFormat = "2006-01-02"
+	dailyHistory    = 28
+
+	prefDailyPrefix = "daily."
+)
+
+// dailyDate returns the date key (in UTC) of the daily deal that is current at the specified time
+func dailyDate(now time.Time) string {
+	return now.UTC().Format(dailyDateFormat)
+}
+
+// dailySeed returns the deal seed for a date key.
+// It uses only the date so every player gets the same layout on the same day without a server.
+func dailySeed(date string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("solitaire-daily-" + date))
+	return int64(h.Sum64() >> 1)
+}
+
+// NewDailyGame starts the daily challenge deal for the date of the specified time.
+// Starting from the date's seed it picks the first deal that the solver can win, searching
+// with a fixed budget so that the result is the same for everyone. If the context stops the
+// search early the deal found would not match the daily deal of other players, so an error is returned.
+// The stock of the daily deal cannot be shuffled, so everyone plays the same cards.
+func NewDailyGame(ctx context.Context, now time.Time, progress func(int)) (*Game, error) {
+	g, ok := NewWinnableGame(ctx, dailySeed(dailyDate(now)), PassesUnlimited, winnableAttempts, solverNodeBudget, progress)
+	if !ok && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	g.FixedStock = true
+	return g, nil
+}
+
+// dailyRecord is the completion of a single daily deal, stored in the app preferences
+type dailyRecord struct {
+	Time  time.Duration
+	Moves int
+}
+
+func loadDailyRecord(p fyne.Preferences, date string) *dailyRecord {
+	millis := p.Int(prefDailyPrefix + date + ".time")
+	if millis == 0 {
+		return nil
+	}
+
+	return &dailyRecord{
+		Time:  time.Duration(millis) * time.Millisecond,
+		Moves: p.Int(prefDailyPrefix + date + ".moves"),
+	}
+}
+
+// saveDailyRecord stores the result for a date, keeping any earlier result that was faster
+func saveDailyRecord(p fyne.Preferences, date string, r *dailyRecord) {
+	if old := loadDailyRecord(p, date); old != nil && old.Time <= r.Time {
+		return
+	}
+
+	millis := int(r.Time / time.Millisecond)
+	if millis == 0 {
+		millis = 1 // zero is used to mean not completed
+	}
+	p.SetInt(prefDailyPrefix+date+".time", millis)
+	p.SetInt(prefDailyPrefix+date+".moves", r.Moves)
+}
+
+// showDailyCalendar lists the recent daily deals and which of them have been completed.
+// The play callback is called if the user chooses to play today's deal.
+func showDailyCalendar(p fyne.Preferences, w fyne.Window, play func()) {
+	now := time.Now()
+	rows := container.NewVBox()
+	for i := 0; i < dailyHistory; i++ {
+		date := dailyDate(now.AddDate(0, 0, -i))
+		status := "-"
+		if r := loadDailyRecord(p, date); r != nil {
+			status = fmt.Sprintf("Completed in %s, %d moves", formatDuration(r.Time), r.Moves)
+		}
+
+		rows.Add(container.NewGridWithColumns(2, widget.NewLabel(date), widget.NewLabel(status)))
+	}
+
+	scroll := container.NewVScroll(rows)
+	scroll.SetMinSize(fyne.NewSize(360, 320))
+	dialog.ShowCustomConfirm("Daily Deals", "Play Today", "Close", scroll, func(ok bool) {
+		if ok {
+			play()
+		}
+	}, w)
+}
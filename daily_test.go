@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyDate(t *testing.T) {
+	late := time.Date(2026, 10, 19, 23, 30, 0, 0, time.FixedZone("West", -5*60*60))
+	assert.Equal(t, "2026-10-20", dailyDate(late))
+	early := time.Date(2026, 10, 20, 0, 10, 0, 0, time.UTC)
+	assert.Equal(t, "2026-10-20", dailyDate(early))
+}
+
+func TestNewDailyGame(t *testing.T) {
+	day := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	g1, err := NewDailyGame(context.Background(), day, nil)
+	assert.NoError(t, err)
+	assert.False(t, g1.CanShuffleStock())
+	g2, _ := NewDailyGame(context.Background(), day.Add(12*time.Hour), nil)
+	assert.Equal(t, g1.Seed, g2.Seed)
+	for i := range g1.Tableau {
+		assert.Equal(t, g1.Tableau[i].Cards, g2.Tableau[i].Cards)
+	}
+	assert.Equal(t, g1.Hand.Cards, g2.Hand.Cards)
+
+	g3, _ := NewDailyGame(context.Background(), day.AddDate(0, 0, 1), nil)
+	assert.NotEqual(t, g1.Seed, g3.Seed)
+
+	result, _ := Solve(context.Background(), g1, solverNodeBudget)
+	assert.Equal(t, SolveFound, result)
+}
+
+func TestNewDailyGame_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	g, err := NewDailyGame(ctx, time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC), nil)
+	assert.Nil(t, g)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestDailyRecord(t *testing.T) {
+	prefs := test.NewApp().Preferences()
+	assert.Nil(t, loadDailyRecord(prefs, "2026-10-19"))
+
+	saveDailyRecord(prefs, "2026-10-19", &dailyRecord{Time: 3 * time.Minute, Moves: 120})
+	saveDailyRecord(prefs, "2026-10-19", &dailyRecord{Time: 4 * time.Minute, Moves: 90})
+	r := loadDailyRecord(prefs, "2026-10-19")
+	assert.Equal(t, 3*time.Minute, r.Time)
+	assert.Equal(t, 120, r.Moves)
+
+	saveDailyRecord(prefs, "2026-10-19", &dailyRecord{Time: 2 * time.Minute, Moves: 100})
+	assert.Equal(t, 100, loadDailyRecord(prefs, "2026-10-19").Moves)
+	assert.Nil(t, loadDailyRecord(prefs, "2026-10-18"))
+}
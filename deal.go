@@ -0,0 +1,237 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The deal notation describes the position of every card, one pile per line, like:
+//
+//	Stock: 7C 2D QS ...
+//	Waste: 4H 9C
+//	Foundation 1: AC 2C
+//	Tableau 1: KD
+//	Tableau 2: #4S 8H
+//
+// Cards are written as value and suit, such as AS, 10H or QD, and face down tableau cards are marked with a '#'.
+// The stock is listed in the order that cards will be drawn and the other piles are listed from the bottom up.
+// The waste and foundation lines may be left out if they are empty.
+
+const (
+	dealStock      = "Stock"
+	dealWaste      = "Waste"
+	dealFoundation = "Foundation"
+	dealTableau    = "Tableau"
+
+	faceDownMarker = "#"
+)
+
+// FormatDeal returns the position of every card in the game using the deal notation
+func (g *Game) FormatDeal() string {
+	b := &strings.Builder{}
+	writePile := func(name string, cards []*Card, markFaceDown bool) {
+		b.WriteString(name + ":")
+		for _, c := range cards {
+			b.WriteString(" ")
+			if markFaceDown && !c.FaceUp {
+				b.WriteString(faceDownMarker)
+			}
+			b.WriteString(c.Notation())
+		}
+		b.WriteString("\n")
+	}
+
+	writePile(dealStock, g.Hand.Cards, false)
+	writePile(dealWaste, g.Drawn.Cards, false)
+	for i, f := range g.Foundations {
+		writePile(fmt.Sprintf("%s %d", dealFoundation, i+1), f.Cards, false)
+	}
+	for i, s := range g.Tableau {
+		writePile(fmt.Sprintf("%s %d", dealTableau, i+1), s.Cards, true)
+	}
+	return b.String()
+}
+
+// ParseDeal creates a game from a position in the deal notation.
+// The position must hold each of the 52 cards exactly once, the foundations must be built up in suit from the ace,
+// and the face down cards of each tableau column must be below its face up cards,
+// which are built down in alternating colours.
+func ParseDeal(text string) (*Game, error) {
+	g := &Game{Hand: &Deck{}, Drawn: &Deck{}, Timer: &Timer{}}
+	g.Foundations = make([]*Stack, FoundationCount)
+	for i := range g.Foundations {
+		g.Foundations[i] = &Stack{}
+	}
+	g.Tableau = make([]*Stack, TableauCount)
+	for i := range g.Tableau {
+		g.Tableau[i] = &Stack{}
+	}
+
+	seen := make(map[string]bool)
+	found := make(map[string]int)
+	for n, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, list, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected a pile name followed by ':'", n+1)
+		}
+		name = strings.TrimSpace(name)
+		if seen[name] {
+			return nil, fmt.Errorf("line %d: %s is listed more than once", n+1, name)
+		}
+		seen[name] = true
+
+		cards, err := parseCardList(list, found)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		if err := g.placeDealPile(name, cards); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+	}
+
+	if !seen[dealStock] {
+		return nil, fmt.Errorf("the %s is missing", dealStock)
+	}
+	for i := range g.Tableau {
+		if name := fmt.Sprintf("%s %d", dealTableau, i+1); !seen[name] {
+			return nil, fmt.Errorf("%s is missing", name)
+		}
+	}
+	if missing := missingCards(found); len(missing) > 0 {
+		return nil, fmt.Errorf("%d cards are missing: %s", len(missing), strings.Join(missing, " "))
+	}
+
+	g.updateWaste()
+	g.faceDown = g.countFaceDown()
+	return g, nil
+}
+
+// placeDealPile puts the cards parsed from a line of the deal notation onto the named pile, checking they are valid there
+func (g *Game) placeDealPile(name string, cards []*Card) error {
+	switch {
+	case name == dealStock:
+		if err := checkFaceUp(name, cards); err != nil {
+			return err
+		}
+		for _, c := range cards {
+			c.FaceUp = false
+		}
+		g.Hand.Cards = cards
+	case name == dealWaste:
+		if err := checkFaceUp(name, cards); err != nil {
+			return err
+		}
+		g.Drawn.Cards = cards
+	case strings.HasPrefix(name, dealFoundation+" "):
+		i, err := pileNumber(name, dealFoundation, FoundationCount)
+		if err != nil {
+			return err
+		}
+		if err := checkFaceUp(name, cards); err != nil {
+			return err
+		}
+		for v, c := range cards {
+			if c.Value != v+1 || c.Suit != cards[0].Suit {
+				return fmt.Errorf("%s must be built up in suit from the ace, found %s", name, c.Notation())
+			}
+		}
+		g.Foundations[i].Cards = cards
+	case strings.HasPrefix(name, dealTableau+" "):
+		i, err := pileNumber(name, dealTableau, TableauCount)
+		if err != nil {
+			return err
+		}
+		if len(cards) > maxStackCards {
+			return fmt.Errorf("%s has %d cards, no more than %d are possible", name, len(cards), maxStackCards)
+		}
+		if err := checkColumn(name, cards); err != nil {
+			return err
+		}
+		g.Tableau[i].Cards = cards
+	default:
+		return fmt.Errorf("unknown pile %q", name)
+	}
+
+	return nil
+}
+
+// parseCardList parses the cards of a pile, noting each in found so that duplicates can be reported.
+// Cards are face up unless they are marked as face down.
+func parseCardList(list string, found map[string]int) ([]*Card, error) {
+	var cards []*Card
+	for _, word := range strings.Fields(list) {
+		faceDown := strings.HasPrefix(word, faceDownMarker)
+		c, err := ParseCard(strings.TrimPrefix(word, faceDownMarker))
+		if err != nil {
+			return nil, err
+		}
+		c.FaceUp = !faceDown
+
+		name := c.Notation()
+		found[name]++
+		if found[name] > 1 {
+			return nil, fmt.Errorf("card %s appears more than once", name)
+		}
+		cards = append(cards, c)
+	}
+	return cards, nil
+}
+
+// checkColumn returns an error if the cards could not be a tableau column: the face down cards must be
+// below the face up cards, with a face up card on top, and the face up cards must be built down in alternating colours.
+func checkColumn(name string, cards []*Card) error {
+	for j, c := range cards {
+		if j == 0 {
+			continue
+		}
+		below := cards[j-1]
+		if !c.FaceUp && below.FaceUp {
+			return fmt.Errorf("%s has face down card %s above a face up card", name, c.Notation())
+		}
+		if c.FaceUp && below.FaceUp && (c.Value != below.Value-1 || c.Color() == below.Color()) {
+			return fmt.Errorf("%s has %s on %s, face up cards must be built down in alternating colours",
+				name, c.Notation(), below.Notation())
+		}
+	}
+	if len(cards) > 0 && !cards[len(cards)-1].FaceUp {
+		return fmt.Errorf("%s must have a face up card on top", name)
+	}
+	return nil
+}
+
+// checkFaceUp returns an error if any of the cards were marked face down, for piles other than the tableau.
+// The stock is always face down so it is not marked.
+func checkFaceUp(name string, cards []*Card) error {
+	for _, c := range cards {
+		if !c.FaceUp {
+			return fmt.Errorf("%s cannot have face down markers", name)
+		}
+	}
+	return nil
+}
+
+// pileNumber returns the index of a numbered pile, such as "Tableau 3", checking that it is in range
+func pileNumber(name, prefix string, count int) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(name, prefix)))
+	if err != nil || n < 1 || n > count {
+		return 0, fmt.Errorf("unknown pile %q, %s must be numbered 1 to %d", name, strings.ToLower(prefix), count)
+	}
+	return n - 1, nil
+}
+
+// missingCards returns the notation for each card of the deck that was not found, in deck order
+func missingCards(found map[string]int) []string {
+	var missing []string
+	for _, c := range NewSortedDeck().Cards {
+		if name := c.Notation(); found[name] == 0 {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testDeal is a position with a few cards played, using every part of the notation
+const testDeal = `Stock: 7C 2D QS 3H 9D JC 4C KS 8S 10C 5D 3D
+Waste: 6S 9H 4D
+Foundation 1: AC 2C 3C
+Foundation 2: AD
+Foundation 3:
+Foundation 4: AS 2S
+Tableau 1: KD QC JH 10S
+Tableau 2: #4S 8H
+Tableau 3: #5C #JS 6D
+Tableau 4: #7D #3S #QH 5H
+Tableau 5: #2H #9S #KC #10H 8D
+Tableau 6: #QD #6H #5S #10D #AH 7S
+Tableau 7: #JD #9C #8C #KH #7H #6C 4H
+`
+
+func TestGame_FormatDeal(t *testing.T) {
+	g := newTestGame()
+	text := g.FormatDeal()
+
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	assert.Equal(t, 1+1+FoundationCount+TableauCount, len(lines))
+	assert.Equal(t, 24, len(strings.Fields(lines[0]))-1)
+	assert.Equal(t, "Waste:", lines[1])
+	assert.Equal(t, "Tableau 1: "+g.Tableau[0].Cards[0].Notation(), lines[6])
+	assert.True(t, strings.HasPrefix(lines[7], "Tableau 2: #"))
+}
+
+func TestParseDeal_RoundTrip(t *testing.T) {
+	g := newTestGame()
+	g.DrawThree()
+	g.AutoBuild(g.WasteTop())
+
+	parsed, err := ParseDeal(g.FormatDeal())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, g.FormatDeal(), parsed.FormatDeal())
+	assert.True(t, cardEquals(g.Draw3, parsed.Draw3))
+	assert.True(t, cardEquals(g.WasteTop(), parsed.WasteTop()))
+
+	parsed, err = ParseDeal(testDeal)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, testDeal, parsed.FormatDeal())
+}
+
+func TestParseDeal_Position(t *testing.T) {
+	g, err := ParseDeal(testDeal)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 12, len(g.Hand.Cards))
+	assert.False(t, g.Hand.Cards[0].FaceUp)
+	assert.Equal(t, &Card{Value: 4, Suit: SuitDiamonds, FaceUp: true}, g.Draw3)
+	assert.Equal(t, 3, len(g.Foundations[0].Cards))
+	assert.Empty(t, g.Foundations[2].Cards)
+	assert.Equal(t, 7, len(g.Tableau[6].Cards))
+	assert.False(t, g.Tableau[6].Cards[5].FaceUp)
+	assert.True(t, g.Tableau[6].Top().FaceUp)
+
+	g.MoveCardToBuild(g.Foundations[1], g.Tableau[5].Top()) // 7S cannot go on AD
+	assert.Equal(t, 1, len(g.Foundations[1].Cards))
+	g.DrawThree()
+	assert.Equal(t, 9, len(g.Hand.Cards))
+	assert.Equal(t, &Card{Value: ValueQueen, Suit: SuitSpades, FaceUp: true}, g.Draw3)
+}
+
+func TestParseDeal_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		from, to, err string
+	}{
+		"duplicate":       {from: "Stock: 7C", to: "Stock: 7C 7C", err: "line 1: card 7C appears more than once"},
+		"missing":         {from: " 5D 3D\n", to: " 5D\n", err: "1 cards are missing: 3D"},
+		"bad card":        {from: "Stock: 7C", to: "Stock: 7X", err: `line 1: invalid card "7X"`},
+		"bad value":       {from: "Stock: 7C", to: "Stock: 1C", err: `line 1: invalid card "1C"`},
+		"unknown pile":    {from: "Waste:", to: "Discard:", err: `line 2: unknown pile "Discard"`},
+		"bad number":      {from: "Tableau 7:", to: "Tableau 8:", err: "tableau must be numbered 1 to 7"},
+		"repeated pile":   {from: "Foundation 3:", to: "Foundation 2:", err: "line 5: Foundation 2 is listed more than once"},
+		"missing column":  {from: "Tableau 1: KD QC JH 10S\n", to: "", err: "Tableau 1 is missing"},
+		"no stock":        {from: "Stock: 7C 2D QS 3H 9D JC 4C KS 8S 10C 5D 3D\n", to: "", err: "the Stock is missing"},
+		"no colon":        {from: "Waste:", to: "Waste", err: "line 2: expected a pile name"},
+		"marked stock":    {from: "Stock: 7C", to: "Stock: #7C", err: "line 1: Stock cannot have face down markers"},
+		"foundation gap":  {from: "AC 2C 3C", to: "AC 3C 2C", err: "Foundation 1 must be built up in suit from the ace, found 3C"},
+		"mixed suits":     {from: "AD\nFoundation 3:\nFoundation 4: AS 2S", to: "AD 2S\nFoundation 3:\nFoundation 4: AS", err: "Foundation 2 must be built up in suit from the ace, found 2S"},
+		"face down above": {from: "#5C #JS 6D", to: "#5C 6D #JS", err: "Tableau 3 has face down card JS above a face up card"},
+		"face down top":   {from: "#2H #9S #KC #10H 8D", to: "#2H #9S #KC 8D #10H", err: "face down card 10H above"},
+		"hidden top":      {from: "KD QC JH 10S", to: "KD QC JH #10S", err: "Tableau 1 has face down card 10S"},
+		"same colour":     {from: "KD QC JH 10S", to: "KD QC JH 10D", err: "Tableau 1 has 10D on JH, face up cards must be built down"},
+		"not descending":  {from: "#4S 8H", to: "#4S 8H 9S", err: "Tableau 2 has 9S on 8H"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Contains(t, testDeal, tc.from)
+			_, err := ParseDeal(strings.Replace(testDeal, tc.from, tc.to, 1))
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+func TestParseDeal_TooManyCards(t *testing.T) {
+	_, err := ParseDeal("Stock:\nTableau 1: #AC #2C #3C #4C #5C #6C #7C #8C #9C #10C #JC #QC #KC #AD #2D #3D #4D #5D #6D 7D")
+	assert.EqualError(t, err, "line 2: Tableau 1 has 20 cards, no more than 19 are possible")
+
+	_, err = ParseDeal("Stock:\nTableau 1: AC 2C 3C 4C 5C 6C 7C 8C 9C 10C JC QC KC AD")
+	assert.EqualError(t, err, "line 2: Tableau 1 has 2C on AC, face up cards must be built down in alternating colours")
+
+	_, err = ParseDeal("Stock:\nTableau 1: #AC 2C")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Tableau 2 is missing")
+}
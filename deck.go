@@ -16,11 +16,11 @@ func (d *Deck) Shuffle() {
 }
 
 // ShuffleFromSeed reorganises the cards in the deck to a random order using
-// the specified seed for rand.Seed().
+// the specified seed. The same seed will always produce the same order.
 func (d *Deck) ShuffleFromSeed(seed int64) {
-	rand.Seed(seed)
+	r := rand.New(rand.NewSource(seed))
 	for c := 0; c < len(d.Cards); c++ {
-		swap := rand.Intn(len(d.Cards))
+		swap := r.Intn(len(d.Cards))
 		if swap != c {
 			d.Cards[swap], d.Cards[c] = d.Cards[c], d.Cards[swap]
 		}
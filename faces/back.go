@@ -0,0 +1,338 @@
+package faces
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"image/color"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+)
+
+// BackPattern is the decoration drawn over the colour of a generated card back
+type BackPattern int
+
+const (
+	// PatternPlain has no decoration
+	PatternPlain BackPattern = iota
+	// PatternStripes draws vertical stripes
+	PatternStripes
+	// PatternCheck draws a chequerboard
+	PatternCheck
+	// PatternDots draws a grid of dots
+	PatternDots
+	// PatternDiamonds draws a grid of diamond shapes
+	PatternDiamonds
+)
+
+// BackEmblem is the optional image drawn in the centre of a generated card back
+type BackEmblem int
+
+const (
+	// EmblemNone leaves the centre of the card back empty
+	EmblemNone BackEmblem = iota
+	// EmblemClub draws a club symbol
+	EmblemClub
+	// EmblemDiamond draws a diamond symbol
+	EmblemDiamond
+	// EmblemHeart draws a heart symbol
+	EmblemHeart
+	// EmblemSpade draws a spade symbol
+	EmblemSpade
+	// EmblemCustom draws the SVG path data in BackDesign.EmblemPath
+	EmblemCustom
+	// EmblemImage draws the PNG or JPEG picture at BackDesign.EmblemURI
+	EmblemImage
+)
+
+var (
+	patternNames = []string{"plain", "stripes", "check", "dots", "diamonds"}
+	emblemNames  = []string{"none", "club", "diamond", "heart", "spade", "custom", "image"}
+
+	// emblem shapes are drawn within a 100x100 box
+	emblemShapes = []string{
+		"",
+		`<circle cx="50" cy="27" r="22"/><circle cx="25" cy="60" r="22"/><circle cx="75" cy="60" r="22"/>` +
+			`<path d="M46 55 L54 55 L60 100 L40 100 Z"/>`,
+		`<path d="M50 0 L88 50 L50 100 L12 50 Z"/>`,
+		`<path d="M50 92 C20 66 0 46 0 28 C0 12 12 2 26 2 C37 2 46 9 50 18 C54 9 63 2 74 2 ` +
+			`C88 2 100 12 100 28 C100 46 80 66 50 92 Z"/>`,
+		`<path d="M50 0 C40 20 0 40 0 62 C0 78 12 88 26 88 C36 88 44 83 47 77 C45 88 40 95 32 100 ` +
+			`L68 100 C60 95 55 88 53 77 C56 83 64 88 74 88 C88 88 100 78 100 62 C100 40 60 20 50 0 Z"/>`,
+	}
+)
+
+// String returns the name of the pattern, as used when storing a design
+func (p BackPattern) String() string {
+	if p < 0 || int(p) >= len(patternNames) {
+		return patternNames[PatternPlain]
+	}
+	return patternNames[p]
+}
+
+// String returns the name of the emblem, as used when storing a design
+func (e BackEmblem) String() string {
+	if e < 0 || int(e) >= len(emblemNames) {
+		return emblemNames[EmblemNone]
+	}
+	return emblemNames[e]
+}
+
+// BackDesign describes a card back that can be generated as an image.
+// EmblemPath is SVG path data, within a 100x100 box, used when Emblem is EmblemCustom.
+// EmblemURI is the location of a picture, used when Emblem is EmblemImage.
+type BackDesign struct {
+	Color      color.NRGBA
+	Pattern    BackPattern
+	Emblem     BackEmblem
+	EmblemPath string
+	EmblemURI  string
+}
+
+// the size of a generated card back, and of the circle that holds its emblem, in SVG units
+const (
+	backWidth, backHeight, backBorder = 167.087, 242.667, 8.0
+	emblemRadius, emblemStroke        = 42.0, 3.0
+)
+
+// ClassicBack is the name of the card back that was drawn for the original set of faces
+const ClassicBack = "Classic"
+
+var (
+	builtinBackNames   = []string{ClassicBack, "Blue Diamonds", "Red Stripes", "Green Check", "Purple Spade"}
+	builtinBackDesigns = map[string]BackDesign{
+		"Blue Diamonds": {Color: color.NRGBA{R: 0x1e, G: 0x4f, B: 0xa0, A: 0xff}, Pattern: PatternDiamonds},
+		"Red Stripes":   {Color: color.NRGBA{R: 0xb0, G: 0x1c, B: 0x24, A: 0xff}, Pattern: PatternStripes},
+		"Green Check":   {Color: color.NRGBA{R: 0x1b, G: 0x6b, B: 0x36, A: 0xff}, Pattern: PatternCheck},
+		"Purple Spade": {Color: color.NRGBA{R: 0x5b, G: 0x2a, B: 0x86, A: 0xff}, Pattern: PatternDots,
+			Emblem: EmblemSpade},
+	}
+
+	backOverride fyne.Resource
+)
+
+// BuiltinBacks returns the names of the card backs that are included in the app
+func BuiltinBacks() []string {
+	return builtinBackNames
+}
+
+// BuiltinBack returns the resource for a named built in card back, or nil if the name is not known
+func BuiltinBack(name string) fyne.Resource {
+	if name == ClassicBack {
+		return cardBackSvg
+	}
+
+	d, ok := builtinBackDesigns[name]
+	if !ok {
+		return nil
+	}
+	return NewBack(d)
+}
+
+// SetBack chooses the image used for the back of cards.
+// Passing nil uses the back from the current deck pack.
+func SetBack(back fyne.Resource) {
+	backOverride = back
+}
+
+// NewBack generates a card back for the specified design.
+// This is an SVG image, unless the emblem is a picture which needs the back to be drawn as a PNG image.
+// If the picture cannot be loaded the back is generated without an emblem.
+func NewBack(d BackDesign) fyne.Resource {
+	// the name must be unique to the design, and picture, as images are cached by resource name
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(d.String()))
+	name := fmt.Sprintf("back-%x", h.Sum32())
+
+	if d.Emblem != EmblemImage {
+		return fyne.NewStaticResource(name+".svg", backSVG(d))
+	}
+	picture, err := readEmblemImage(d.EmblemURI)
+	var data []byte
+	if err == nil {
+		data, err = drawPictureBack(backSVG(d), picture)
+	}
+	if err != nil {
+		fyne.LogError("Unable to draw card back image "+d.EmblemURI, err)
+		d.Emblem = EmblemNone
+		return fyne.NewStaticResource(name+".svg", backSVG(d))
+	}
+	_, _ = h.Write(picture)
+	return fyne.NewStaticResource(fmt.Sprintf("back-%x.png", h.Sum32()), data)
+}
+
+// backSVG returns the SVG for a design, leaving the emblem circle empty if the emblem is a picture
+func backSVG(d BackDesign) []byte {
+	const width, height, border = backWidth, backHeight, backBorder
+	fill := HexColor(d.Color)
+	light := HexColor(mix(d.Color, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}, 0.35))
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="222.78267" height="323.556" viewBox="0 0 %g %g">`,
+		width, height)
+	fmt.Fprintf(b, `<rect x="0.5" y="0.5" width="%g" height="%g" rx="10" fill="#ffffff" stroke="#000000"/>`,
+		width-1, height-1)
+	innerW, innerH := width-border*2, height-border*2
+	fmt.Fprintf(b, `<rect x="%g" y="%g" width="%g" height="%g" rx="6" fill="%s"/>`, border, border, innerW, innerH, fill)
+
+	b.WriteString(`<g fill="` + light + `">`)
+	writePattern(b, d.Pattern, border, border, innerW, innerH)
+	b.WriteString(`</g>`)
+
+	shape := ""
+	if d.Emblem == EmblemCustom && ValidEmblemPath(d.EmblemPath) {
+		shape = `<path d="` + d.EmblemPath + `"/>`
+	} else if d.Emblem > EmblemNone && int(d.Emblem) < len(emblemShapes) {
+		shape = emblemShapes[d.Emblem]
+	}
+	cx, cy := width/2, height/2
+	if shape != "" || d.Emblem == EmblemImage {
+		fmt.Fprintf(b, `<circle cx="%g" cy="%g" r="%g" fill="#ffffff" stroke="%s" stroke-width="%g"/>`,
+			cx, cy, emblemRadius, fill, emblemStroke)
+	}
+	if shape != "" {
+		fmt.Fprintf(b, `<g fill="%s" transform="matrix(0.5 0 0 0.5 %g %g)">%s</g>`, fill, cx-25, cy-25, shape)
+	}
+	b.WriteString(`</svg>`)
+	return []byte(b.String())
+}
+
+// ValidEmblemPath returns true if the string contains only SVG path data characters
+func ValidEmblemPath(path string) bool {
+	if strings.TrimSpace(path) == "" {
+		return false
+	}
+
+	for _, r := range path {
+		if !strings.ContainsRune("0123456789.,-+eE MmLlHhVvCcSsQqTtAaZz\t\n", r) {
+			return false
+		}
+	}
+	return true
+}
+
+func writePattern(b *strings.Builder, p BackPattern, x, y, w, h float64) {
+	const step = 15.0
+	cols, rows := int(w/step), int(h/step)
+	padX, padY := (w-float64(cols)*step)/2, (h-float64(rows)*step)/2
+
+	switch p {
+	case PatternStripes:
+		for i := 0; i < cols; i += 2 {
+			fmt.Fprintf(b, `<rect x="%g" y="%g" width="%g" height="%g"/>`, x+padX+float64(i)*step, y, step, h)
+		}
+	case PatternCheck:
+		for r := 0; r < rows; r++ {
+			for c := r % 2; c < cols; c += 2 {
+				fmt.Fprintf(b, `<rect x="%g" y="%g" width="%g" height="%g"/>`,
+					x+padX+float64(c)*step, y+padY+float64(r)*step, step, step)
+			}
+		}
+	case PatternDots:
+		for r := 0; r < rows; r++ {
+			for c := 0; c < cols; c++ {
+				fmt.Fprintf(b, `<circle cx="%g" cy="%g" r="%g"/>`,
+					x+padX+(float64(c)+0.5)*step, y+padY+(float64(r)+0.5)*step, step/4)
+			}
+		}
+	case PatternDiamonds:
+		for r := 0; r < rows; r++ {
+			for c := 0; c < cols; c++ {
+				cx, cy := x+padX+(float64(c)+0.5)*step, y+padY+(float64(r)+0.5)*step
+				fmt.Fprintf(b, `<path d="M%g %g L%g %g L%g %g L%g %g Z"/>`,
+					cx, cy-step/2, cx+step/3, cy, cx, cy+step/2, cx-step/3, cy)
+			}
+		}
+	}
+}
+
+// String encodes the design so it can be stored, in the format "#rrggbb/pattern/emblem[/path or URI]"
+func (d BackDesign) String() string {
+	s := HexColor(d.Color) + "/" + d.Pattern.String() + "/" + d.Emblem.String()
+	switch d.Emblem {
+	case EmblemCustom:
+		s += "/" + d.EmblemPath
+	case EmblemImage:
+		s += "/" + d.EmblemURI
+	}
+	return s
+}
+
+// ParseBackDesign decodes a design that was encoded using BackDesign.String()
+func ParseBackDesign(s string) (BackDesign, error) {
+	d := BackDesign{}
+	parts := strings.SplitN(s, "/", 4)
+	if len(parts) < 3 {
+		return d, errors.New("invalid card back design: " + s)
+	}
+
+	c, err := ParseHexColor(parts[0])
+	if err != nil {
+		return d, fmt.Errorf("invalid card back colour: %w", err)
+	}
+	d.Color = c
+
+	d.Pattern = BackPattern(indexOf(patternNames, parts[1]))
+	d.Emblem = BackEmblem(indexOf(emblemNames, parts[2]))
+	if d.Pattern < 0 || d.Emblem < 0 {
+		return d, errors.New("invalid card back design: " + s)
+	}
+	if d.Emblem == EmblemCustom {
+		if len(parts) < 4 || !ValidEmblemPath(parts[3]) {
+			return d, errors.New("missing or invalid custom emblem path: " + s)
+		}
+		d.EmblemPath = parts[3]
+	}
+	if d.Emblem == EmblemImage {
+		if len(parts) < 4 {
+			return d, errors.New("missing card back image: " + s)
+		}
+		if _, err := storage.ParseURI(parts[3]); err != nil {
+			return d, fmt.Errorf("invalid card back image: %w", err)
+		}
+		d.EmblemURI = parts[3]
+	}
+	return d, nil
+}
+
+// PatternNames returns the names of the available patterns, in the order of the BackPattern values
+func PatternNames() []string {
+	return patternNames
+}
+
+// EmblemNames returns the names of the available emblems, in the order of the BackEmblem values
+func EmblemNames() []string {
+	return emblemNames
+}
+
+func indexOf(list []string, item string) int {
+	for i, s := range list {
+		if s == item {
+			return i
+		}
+	}
+	return -1
+}
+
+// ParseHexColor decodes an opaque colour in the "#rrggbb" format, as used in stored preferences
+func ParseHexColor(s string) (color.NRGBA, error) {
+	var r, g, b uint8
+	if _, err := fmt.Sscanf(s, "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return color.NRGBA{}, fmt.Errorf("invalid colour %q: %w", s, err)
+	}
+	return color.NRGBA{R: r, G: g, B: b, A: 0xff}, nil
+}
+
+// HexColor encodes a colour in the "#rrggbb" format read by ParseHexColor, ignoring its alpha
+func HexColor(c color.NRGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func mix(c1, c2 color.NRGBA, amount float64) color.NRGBA {
+	blend := func(a, b uint8) uint8 {
+		return uint8(float64(a)*(1-amount) + float64(b)*amount)
+	}
+	return color.NRGBA{R: blend(c1.R, c2.R), G: blend(c1.G, c2.G), B: blend(c1.B, c2.B), A: 0xff}
+}
@@ -0,0 +1,113 @@
+package faces
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackDesign_String(t *testing.T) {
+	d := BackDesign{Color: color.NRGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xff}, Pattern: PatternDots, Emblem: EmblemHeart}
+	assert.Equal(t, "#123456/dots/heart", d.String())
+
+	parsed, err := ParseBackDesign(d.String())
+	assert.NoError(t, err)
+	assert.Equal(t, d, parsed)
+
+	d.Emblem = EmblemCustom
+	d.EmblemPath = "M0 0 L100 0 L50 100 Z"
+	parsed, err = ParseBackDesign(d.String())
+	assert.NoError(t, err)
+	assert.Equal(t, d, parsed)
+}
+
+func TestParseBackDesign_Invalid(t *testing.T) {
+	for _, s := range []string{"", "#123456", "red/dots/none", "#123456/zigzag/none", "#123456/dots/custom",
+		"#123456/dots/image",
+		`#123456/dots/custom/M0 0"/><script/>`} {
+		_, err := ParseBackDesign(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	c, err := ParseHexColor("#1e4fa0")
+	assert.NoError(t, err)
+	assert.Equal(t, color.NRGBA{R: 0x1e, G: 0x4f, B: 0xa0, A: 0xff}, c)
+	assert.Equal(t, "#1e4fa0", HexColor(c))
+
+	_, err = ParseHexColor("green")
+	assert.Error(t, err)
+}
+
+func TestNewBack(t *testing.T) {
+	d := BackDesign{Color: color.NRGBA{R: 0x1e, G: 0x4f, B: 0xa0, A: 0xff}, Pattern: PatternCheck, Emblem: EmblemClub}
+	back := NewBack(d)
+	svg := string(back.Content())
+	assert.True(t, strings.HasSuffix(back.Name(), ".svg"))
+	assert.True(t, strings.HasPrefix(svg, "<svg"))
+	assert.Contains(t, svg, `fill="#1e4fa0"`)
+	assert.Contains(t, svg, "<circle")
+
+	d.Pattern = PatternStripes
+	assert.NotEqual(t, back.Name(), NewBack(d).Name())
+}
+
+func TestNewBack_Image(t *testing.T) {
+	test.NewTempApp(t) // for the file storage repository
+	picture := image.NewNRGBA(image.Rect(0, 0, 40, 20))
+	draw.Draw(picture, picture.Bounds(), image.NewUniform(color.NRGBA{R: 0xff, A: 0xff}), image.Point{}, draw.Src)
+	file := filepath.Join(t.TempDir(), "emblem.png")
+	out, err := os.Create(file)
+	assert.NoError(t, err)
+	assert.NoError(t, png.Encode(out, picture))
+	_ = out.Close()
+
+	d := BackDesign{Color: color.NRGBA{R: 0x1e, G: 0x4f, B: 0xa0, A: 0xff}, Emblem: EmblemImage,
+		EmblemURI: storage.NewFileURI(file).String()}
+	parsed, err := ParseBackDesign(d.String())
+	assert.NoError(t, err)
+	assert.Equal(t, d, parsed)
+
+	back := NewBack(d)
+	assert.True(t, strings.HasSuffix(back.Name(), ".png"))
+	img, err := png.Decode(bytes.NewReader(back.Content()))
+	if !assert.NoError(t, err) {
+		return
+	}
+	size := img.Bounds().Size()
+	assert.Equal(t, color.NRGBA{R: 0xff, A: 0xff}, color.NRGBAModel.Convert(img.At(size.X/2, size.Y/2)))
+	assert.Equal(t, color.NRGBA{R: 0x1e, G: 0x4f, B: 0xa0, A: 0xff}, color.NRGBAModel.Convert(img.At(size.X/2, 40)))
+
+	// a new picture saved over the old file must not be shown from the image cache
+	picture.Set(0, 0, color.NRGBA{B: 0xff, A: 0xff})
+	out, _ = os.Create(file)
+	assert.NoError(t, png.Encode(out, picture))
+	_ = out.Close()
+	assert.NotEqual(t, back.Name(), NewBack(d).Name())
+
+	d.EmblemURI = storage.NewFileURI(filepath.Join(t.TempDir(), "missing.png")).String()
+	assert.True(t, strings.HasSuffix(NewBack(d).Name(), ".svg"))
+}
+
+func TestSetBack(t *testing.T) {
+	assert.Equal(t, cardBackSvg, ForBack())
+
+	blue := BuiltinBack("Blue Diamonds")
+	SetBack(blue)
+	assert.Equal(t, blue, ForBack())
+
+	SetBack(nil)
+	assert.Equal(t, cardBackSvg, ForBack())
+	assert.Nil(t, BuiltinBack("Unknown"))
+}
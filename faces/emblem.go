@@ -0,0 +1,97 @@
+package faces
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/jpeg" // register the formats that can be chosen for a card back image
+	"image/png"
+	"io"
+	"math"
+
+	"fyne.io/fyne/v2/storage"
+	"github.com/fyne-io/oksvg"
+	"github.com/srwiley/rasterx"
+	"golang.org/x/image/draw"
+)
+
+// backImageScale is how many pixels a picture back has for each unit of the SVG it is drawn from
+const backImageScale = 2
+
+// readEmblemImage reads the image file at a URI, for use as a card back emblem
+func readEmblemImage(uri string) ([]byte, error) {
+	u, err := storage.ParseURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := storage.Reader(u)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
+
+// drawPictureBack draws an SVG card back to an image and fills the emblem circle at its centre with a PNG
+// or JPEG picture. The picture is cropped to a square from its centre and the result is encoded as a PNG.
+func drawPictureBack(svg, pictureData []byte) (data []byte, err error) {
+	picture, _, err := image.Decode(bytes.NewReader(pictureData))
+	if err != nil {
+		return nil, err
+	}
+	icon, err := oksvg.ReadIconStream(bytes.NewReader(svg))
+	if err != nil {
+		return nil, err
+	}
+
+	w, h := int(math.Round(backWidth*backImageScale)), int(math.Round(backHeight*backImageScale))
+	icon.SetTarget(0, 0, float64(w), float64(h))
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	scanner := rasterx.NewScannerGV(w, h, img, img.Bounds())
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New("unable to draw card back")
+		}
+	}()
+	icon.Draw(rasterx.NewDasher(w, h, scanner), 1)
+
+	mask := &circleMask{center: image.Pt(w/2, h/2), radius: int((emblemRadius - emblemStroke) * backImageScale)}
+	src := picture.Bounds()
+	side := src.Dx()
+	if src.Dy() < side {
+		side = src.Dy()
+	}
+	crop := image.Rect(0, 0, side, side).Add(src.Min).Add(image.Pt((src.Dx()-side)/2, (src.Dy()-side)/2))
+	draw.CatmullRom.Scale(img, mask.Bounds(), picture, crop, draw.Over, &draw.Options{DstMask: mask})
+
+	b := &bytes.Buffer{}
+	if err := png.Encode(b, img); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// circleMask is an image that is opaque within a circle and transparent outside it
+type circleMask struct {
+	center image.Point
+	radius int
+}
+
+func (c *circleMask) ColorModel() color.Model {
+	return color.AlphaModel
+}
+
+func (c *circleMask) Bounds() image.Rectangle {
+	return image.Rect(c.center.X-c.radius, c.center.Y-c.radius, c.center.X+c.radius, c.center.Y+c.radius)
+}
+
+func (c *circleMask) At(x, y int) color.Color {
+	dx, dy := x-c.center.X, y-c.center.Y
+	if dx*dx+dy*dy <= c.radius*c.radius {
+		return color.Alpha{A: 0xff}
+	}
+	return color.Alpha{}
+}
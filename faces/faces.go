@@ -4,19 +4,23 @@ package faces
 
 import "fyne.io/fyne/v2"
 
-// ForCard returns the face resource for the specified card value and suit.
+// ForCard returns the face resource for the specified card value and suit from the current pack.
 func ForCard(card, suit int) fyne.Resource {
-	return faceResources[card-1+(suit*13)]
+	return current.faces[card-1+(suit*13)]
 }
 
-// ForBack returns a face resource for the back of a card
+// ForBack returns a face resource for the back of a card.
+// This is the back chosen with SetBack, if any, otherwise the back from the current pack.
 func ForBack() fyne.Resource {
-	return cardBackSvg
+	if backOverride != nil {
+		return backOverride
+	}
+	return current.back
 }
 
 // ForSpace returns a special resource to use when a vacant spot should be indicated
 func ForSpace() fyne.Resource {
-	return cardSpaceSvg
+	return current.space
 }
 
 var faceResources = [52]fyne.Resource{
@@ -0,0 +1,276 @@
+package faces
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+)
+
+// ManifestName is the name of the file describing a deck pack
+const ManifestName = "manifest.json"
+
+var (
+	valueNames = []string{"A", "2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K"}
+	suitNames  = []string{"C", "D", "H", "S"}
+)
+
+// Manifest describes a deck pack.
+// Extension is the file type of the images in the pack, for example "svg" or "png".
+type Manifest struct {
+	Name      string `json:"name"`
+	Author    string `json:"author,omitempty"`
+	Extension string `json:"extension"`
+}
+
+// Pack is a set of images used to draw the cards.
+// A pack has an image for each of the 52 faces (named like "AS.svg" or "10H.png"),
+// a "back" image, a "space" image for vacant spots and a manifest.
+type Pack struct {
+	Manifest Manifest
+
+	faces       [52]fyne.Resource
+	back, space fyne.Resource
+}
+
+// PackError reports the problems found when validating a deck pack.
+// Misnamed maps files that are named incorrectly to the name they should have.
+type PackError struct {
+	Missing    []string
+	Unexpected []string
+	Misnamed   map[string]string
+}
+
+func (e *PackError) Error() string {
+	var msg []string
+	if len(e.Misnamed) > 0 {
+		var names []string
+		for from, to := range e.Misnamed {
+			names = append(names, from+" should be "+to)
+		}
+		sort.Strings(names)
+		msg = append(msg, "misnamed files: "+strings.Join(names, ", "))
+	}
+	if len(e.Missing) > 0 {
+		msg = append(msg, "missing files: "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Unexpected) > 0 {
+		msg = append(msg, "unexpected files: "+strings.Join(e.Unexpected, ", "))
+	}
+	return "invalid deck pack, " + strings.Join(msg, "; ")
+}
+
+var (
+	defaultPack = &Pack{Manifest: Manifest{Name: "Default", Extension: "svg"},
+		faces: faceResources, back: cardBackSvg, space: cardSpaceSvg}
+	current = defaultPack
+)
+
+// DefaultPack returns the built in deck pack
+func DefaultPack() *Pack {
+	return defaultPack
+}
+
+// CurrentPack returns the deck pack that is used to draw cards
+func CurrentPack() *Pack {
+	return current
+}
+
+// SetPack changes the deck pack that is used to draw cards, passing nil restores the default
+func SetPack(p *Pack) {
+	if p == nil {
+		p = defaultPack
+	}
+	current = p
+}
+
+// fileName returns the name of the face image for a card value (1 based) and suit
+func fileName(card, suit int, ext string) string {
+	return valueNames[card-1] + suitNames[suit] + "." + ext
+}
+
+// NewPack creates a deck pack from a set of files keyed by file name.
+// If any files are missing, or there are files that are not part of a pack, a *PackError is returned.
+func NewPack(files map[string][]byte) (*Pack, error) {
+	data, ok := files[ManifestName]
+	if !ok {
+		return nil, &PackError{Missing: []string{ManifestName}}
+	}
+
+	p := &Pack{}
+	if err := json.Unmarshal(data, &p.Manifest); err != nil {
+		return nil, fmt.Errorf("invalid deck pack manifest: %w", err)
+	}
+	if p.Manifest.Extension == "" {
+		return nil, errors.New("invalid deck pack manifest: no extension specified")
+	}
+
+	prefix := packPrefix(files)
+	expected := map[string]bool{ManifestName: true}
+	problems := &PackError{}
+	load := func(name string) fyne.Resource {
+		expected[name] = true
+		content, ok := files[name]
+		if !ok {
+			problems.Missing = append(problems.Missing, name)
+			return nil
+		}
+
+		return fyne.NewStaticResource(prefix+name, content)
+	}
+
+	ext := p.Manifest.Extension
+	for suit := range suitNames {
+		for card := 1; card <= len(valueNames); card++ {
+			p.faces[card-1+suit*len(valueNames)] = load(fileName(card, suit, ext))
+		}
+	}
+	p.back = load("back." + ext)
+	p.space = load("space." + ext)
+
+	for name := range files {
+		if !expected[name] && !ignoredFile(name) {
+			problems.Unexpected = append(problems.Unexpected, name)
+		}
+	}
+
+	problems.findMisnamed()
+	if len(problems.Missing) > 0 || len(problems.Unexpected) > 0 || len(problems.Misnamed) > 0 {
+		sort.Strings(problems.Unexpected)
+		return nil, problems
+	}
+	return p, nil
+}
+
+// findMisnamed moves unexpected files that differ only by case, or extension, from a missing file into Misnamed
+func (e *PackError) findMisnamed() {
+	var unexpected []string
+	for _, name := range e.Unexpected {
+		match := -1
+		for i, missing := range e.Missing {
+			if strings.EqualFold(name, missing) || strings.EqualFold(stripExt(name), stripExt(missing)) {
+				match = i
+				break
+			}
+		}
+		if match < 0 {
+			unexpected = append(unexpected, name)
+			continue
+		}
+
+		if e.Misnamed == nil {
+			e.Misnamed = make(map[string]string)
+		}
+		e.Misnamed[name] = e.Missing[match]
+		e.Missing = append(e.Missing[:match], e.Missing[match+1:]...)
+	}
+	e.Unexpected = unexpected
+}
+
+func stripExt(name string) string {
+	return strings.TrimSuffix(name, path.Ext(name))
+}
+
+// LoadPack reads a deck pack from a directory or a zip file using the storage API
+func LoadPack(u fyne.URI) (*Pack, error) {
+	if strings.EqualFold(u.Extension(), ".zip") {
+		data, err := readURI(u)
+		if err != nil {
+			return nil, err
+		}
+		return loadZip(data)
+	}
+
+	items, err := storage.List(u)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	for _, item := range items {
+		if ok, _ := storage.CanList(item); ok {
+			files[item.Name()+"/"] = nil
+			continue
+		}
+
+		data, err := readURI(item)
+		if err != nil {
+			return nil, err
+		}
+		files[item.Name()] = data
+	}
+	return NewPack(files)
+}
+
+// packPrefix returns a name prefix unique to the content of a pack, as images are cached by resource name
+func packPrefix(files map[string][]byte) string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := fnv.New32a()
+	for _, name := range names {
+		_, _ = h.Write([]byte(name))
+		_, _ = h.Write(files[name])
+	}
+	return fmt.Sprintf("pack-%x-", h.Sum32())
+}
+
+func loadZip(data []byte) (*Pack, error) {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		in, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(in)
+		_ = in.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		// folders are ignored, but a second file of the same name is kept by its full path to be reported as unexpected
+		name := path.Base(f.Name)
+		if _, ok := files[name]; ok && !ignoredFile(name) {
+			name = f.Name
+		}
+		files[name] = content
+	}
+	return NewPack(files)
+}
+
+func readURI(u fyne.URI) ([]byte, error) {
+	r, err := storage.Reader(u)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
+
+// ignoredFile returns true for files that commonly appear in folders but are not part of a pack
+func ignoredFile(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.EqualFold(name, "README.md") ||
+		strings.EqualFold(name, "LICENSE") || strings.HasSuffix(name, ".txt")
+}
@@ -0,0 +1,124 @@
+package faces
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testPackFiles() map[string][]byte {
+	files := map[string][]byte{
+		ManifestName: []byte(`{"name": "Test", "extension": "png"}`),
+		"back.png":   []byte("back"),
+		"space.png":  []byte("space"),
+	}
+	for suit := range suitNames {
+		for card := 1; card <= 13; card++ {
+			name := fileName(card, suit, "png")
+			files[name] = []byte(name)
+		}
+	}
+	return files
+}
+
+func TestNewPack(t *testing.T) {
+	files := testPackFiles()
+	files[".DS_Store"] = []byte{}
+
+	p, err := NewPack(files)
+	assert.NoError(t, err)
+	assert.Equal(t, "Test", p.Manifest.Name)
+	assert.True(t, strings.HasSuffix(p.faces[11+2*13].Name(), "-QH.png"))
+	assert.Equal(t, []byte("back"), p.back.Content())
+}
+
+func TestNewPack_Invalid(t *testing.T) {
+	files := testPackFiles()
+	delete(files, "10S.png")
+	delete(files, "AD.png")
+	files["ad.png"] = []byte("ad")
+	files["joker.png"] = []byte("joker")
+
+	_, err := NewPack(files)
+	assert.Error(t, err)
+	perr := err.(*PackError)
+	assert.Equal(t, []string{"10S.png"}, perr.Missing)
+	assert.Equal(t, []string{"joker.png"}, perr.Unexpected)
+	assert.Equal(t, map[string]string{"ad.png": "AD.png"}, perr.Misnamed)
+	assert.Equal(t, "invalid deck pack, misnamed files: ad.png should be AD.png; "+
+		"missing files: 10S.png; unexpected files: joker.png", err.Error())
+
+	delete(files, ManifestName)
+	_, err = NewPack(files)
+	assert.Equal(t, []string{ManifestName}, err.(*PackError).Missing)
+}
+
+func TestLoadZip(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for name, content := range testPackFiles() {
+		f, _ := w.Create("mypack/" + name)
+		_, _ = f.Write(content)
+	}
+	assert.NoError(t, w.Close())
+
+	p, err := loadZip(buf.Bytes())
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(p.faces[0].Name(), "-AC.png"))
+}
+
+func TestLoadZip_DuplicateName(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for name, content := range testPackFiles() {
+		f, _ := w.Create("mypack/" + name)
+		_, _ = f.Write(content)
+	}
+	f, _ := w.Create("extra/AS.png")
+	_, _ = f.Write([]byte("another ace"))
+	f, _ = w.Create("extra/.DS_Store")
+	_, _ = f.Write([]byte{})
+	f, _ = w.Create("mypack/.DS_Store")
+	_, _ = f.Write([]byte{})
+	assert.NoError(t, w.Close())
+
+	_, err := loadZip(buf.Bytes())
+	assert.Error(t, err)
+	if perr, ok := err.(*PackError); assert.True(t, ok) {
+		assert.Equal(t, []string{"extra/AS.png"}, perr.Unexpected)
+		assert.Empty(t, perr.Missing)
+	}
+}
+
+func TestSetPack(t *testing.T) {
+	p, _ := NewPack(testPackFiles())
+	SetPack(p)
+	assert.Equal(t, p.faces[51], ForCard(13, 3))
+	assert.True(t, strings.HasSuffix(ForBack().Name(), "-back.png"))
+
+	SetPack(nil)
+	assert.Equal(t, cardKSSvg, ForCard(13, 3))
+	assert.Equal(t, DefaultPack(), CurrentPack())
+}
+
+func TestNewPack_UniqueNames(t *testing.T) {
+	files := map[string][]byte{ManifestName: []byte(`{"name": "Plain", "extension": "svg"}`)}
+	for _, res := range append(faceResources[:], cardBackSvg, cardSpaceSvg) {
+		files[res.Name()] = []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`)
+	}
+	p, err := NewPack(files)
+	if !assert.NoError(t, err) {
+		return
+	}
+	for i, face := range faceResources {
+		assert.NotEqual(t, face.Name(), p.faces[i].Name())
+	}
+	assert.NotEqual(t, cardBackSvg.Name(), p.back.Name())
+
+	files["AS.svg"] = []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="1"/>`)
+	other, _ := NewPack(files)
+	assert.NotEqual(t, p.faces[0].Name(), other.faces[0].Name())
+}
@@ -0,0 +1,216 @@
+package faces
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+// Palette chooses the colours used for each suit on generated faces
+type Palette int
+
+const (
+	// PaletteTwoColour draws clubs and spades in black and diamonds and hearts in red
+	PaletteTwoColour Palette = iota
+	// PaletteFourColour gives each suit its own colour: green clubs, blue diamonds, red hearts and black spades
+	PaletteFourColour
+)
+
+var suitColours = [][]string{
+	PaletteTwoColour:  {"#000000", "#d40000", "#d40000", "#000000"},
+	PaletteFourColour: {"#00802b", "#0050d0", "#d40000", "#000000"},
+}
+
+// FaceStyle describes a set of card faces that can be generated as SVG images.
+// LargeIndex draws the value and suit big enough to read at small card sizes,
+// replacing the pips with a single large suit symbol and using heavier lines.
+type FaceStyle struct {
+	Palette    Palette
+	LargeIndex bool
+}
+
+const (
+	// FourColourPack is the name of the generated pack with a different colour for each suit
+	FourColourPack = "Four Colour"
+	// HighContrastPack is the name of the generated pack with large indices
+	HighContrastPack = "High Contrast"
+	// HighContrastFourColourPack is the name of the generated pack with large indices and four colours
+	HighContrastFourColourPack = "High Contrast Four Colour"
+)
+
+var (
+	builtinPackNames  = []string{"Default", FourColourPack, HighContrastPack, HighContrastFourColourPack}
+	builtinPackStyles = map[string]FaceStyle{
+		FourColourPack:             {Palette: PaletteFourColour},
+		HighContrastPack:           {LargeIndex: true},
+		HighContrastFourColourPack: {Palette: PaletteFourColour, LargeIndex: true},
+	}
+	generatedPacks = map[string]*Pack{}
+
+	// glyphs are stroked paths within a box 10 wide (13 for "10") and 16 high
+	glyphs = []string{
+		"M0 16 L5 0 L10 16 M2.2 10 L7.8 10",
+		"M0.5 4 C0.5 -0.5 9.5 -0.5 9.5 4 C9.5 8 0 11 0 16 L10 16",
+		"M0.5 0.5 L9.5 0.5 L4.5 6.5 C10 6.5 10.5 11 9.5 13 C8 16.5 2 16.5 0.5 13.5",
+		"M7.5 16 L7.5 0 L0 11 L10 11",
+		"M9.5 0 L1.5 0 L0.5 7 C4 5 10 5.5 10 10.5 C10 16.5 2 17 0 13.5",
+		"M8.5 0.5 C3 0 0 5 0 10.5 C0 14 2 16 5 16 C8 16 10 14 10 11 C10 8 8 6.5 5 6.5 C2.5 6.5 0.5 8 0 10",
+		"M0 0 L10 0 L4 16",
+		"M5 7.2 C1.5 7.2 1 5.5 1 3.8 C1 1.5 2.8 0 5 0 C7.2 0 9 1.5 9 3.8 C9 5.5 8.5 7.2 5 7.2 " +
+			"C1.5 7.2 0 9 0 11.5 C0 14.5 2.2 16 5 16 C7.8 16 10 14.5 10 11.5 C10 9 8.5 7.2 5 7.2 Z",
+		"M1.5 15.5 C7 16 10 11 10 5.5 C10 2 8 0 5 0 C2 0 0 2 0 5 C0 8 2 9.5 5 9.5 C7.5 9.5 9.5 8 10 6",
+		"M0 3 L3 0 L3 16 M9.5 0 C6.5 0 6 4 6 8 C6 12 6.5 16 9.5 16 C12.5 16 13 12 13 8 C13 4 12.5 0 9.5 0 Z",
+		"M3 0 L10 0 M7.5 0 L7.5 11.5 C7.5 15 5.5 16 3.8 16 C1.5 16 0 14.5 0 12",
+		"M5 0 C1 0 0 4 0 7.5 C0 11 1 15 5 15 C9 15 10 11 10 7.5 C10 4 9 0 5 0 Z M6 11 L10 16",
+		"M0 0 L0 16 M10 0 L0 10 M3.5 6.8 L10 16",
+	}
+
+	// pip positions for each number card, as fractions of the pip area in columns and rows
+	pipLayouts = [][][2]float64{
+		{{0.5, 0.5}},
+		{{0.5, 0}, {0.5, 1}},
+		{{0.5, 0}, {0.5, 0.5}, {0.5, 1}},
+		{{0, 0}, {1, 0}, {0, 1}, {1, 1}},
+		{{0, 0}, {1, 0}, {0.5, 0.5}, {0, 1}, {1, 1}},
+		{{0, 0}, {1, 0}, {0, 0.5}, {1, 0.5}, {0, 1}, {1, 1}},
+		{{0, 0}, {1, 0}, {0.5, 0.25}, {0, 0.5}, {1, 0.5}, {0, 1}, {1, 1}},
+		{{0, 0}, {1, 0}, {0.5, 0.25}, {0, 0.5}, {1, 0.5}, {0.5, 0.75}, {0, 1}, {1, 1}},
+		{{0, 0}, {1, 0}, {0, 1.0 / 3}, {1, 1.0 / 3}, {0.5, 0.5}, {0, 2.0 / 3}, {1, 2.0 / 3}, {0, 1}, {1, 1}},
+		{{0, 0}, {1, 0}, {0.5, 1.0 / 6}, {0, 1.0 / 3}, {1, 1.0 / 3}, {0, 2.0 / 3}, {1, 2.0 / 3}, {0.5, 5.0 / 6},
+			{0, 1}, {1, 1}},
+	}
+)
+
+// BuiltinPacks returns the names of the deck packs that are included in the app.
+// The first is the default pack, the others are generated from a FaceStyle.
+func BuiltinPacks() []string {
+	return builtinPackNames
+}
+
+// BuiltinPack returns a named built in deck pack, or nil if the name is not known.
+// Generated packs are created the first time they are requested.
+func BuiltinPack(name string) *Pack {
+	if name == defaultPack.Manifest.Name {
+		return defaultPack
+	}
+
+	if p, ok := generatedPacks[name]; ok {
+		return p
+	}
+	style, ok := builtinPackStyles[name]
+	if !ok {
+		return nil
+	}
+	p := NewStylePack(name, style)
+	generatedPacks[name] = p
+	return p
+}
+
+// NewStylePack generates a deck pack with faces drawn in the specified style.
+// Only the appearance changes, a card is still red or black for the rules whatever the palette.
+func NewStylePack(name string, style FaceStyle) *Pack {
+	p := &Pack{Manifest: Manifest{Name: name, Extension: "svg"}, back: cardBackSvg, space: cardSpaceSvg}
+	prefix := style.resourcePrefix()
+	for suit := range suitNames {
+		for card := 1; card <= len(valueNames); card++ {
+			svg := style.face(card, suit)
+			p.faces[card-1+suit*len(valueNames)] = fyne.NewStaticResource(prefix+fileName(card, suit, "svg"), []byte(svg))
+		}
+	}
+	return p
+}
+
+// resourcePrefix returns a name prefix unique to the style, as images are cached by resource name
+func (s FaceStyle) resourcePrefix() string {
+	prefix := "face-2c-"
+	if s.Palette == PaletteFourColour {
+		prefix = "face-4c-"
+	}
+	if s.LargeIndex {
+		prefix += "large-"
+	}
+	return prefix
+}
+
+func (s FaceStyle) colour(suit int) string {
+	palette := suitColours[PaletteTwoColour]
+	if s.Palette >= 0 && int(s.Palette) < len(suitColours) {
+		palette = suitColours[s.Palette]
+	}
+	return palette[suit]
+}
+
+// face generates the SVG for a card value (1 based) and suit
+func (s FaceStyle) face(card, suit int) string {
+	const width, height = 167.087, 242.667
+	fill := s.colour(suit)
+	border, stroke, glyphHeight, pipSize := 1.0, 2.0, 22.0, 16.0
+	if s.LargeIndex {
+		border, stroke, glyphHeight, pipSize = 3.0, 3.0, 44.0, 30.0
+	}
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="222.78267" height="323.556" viewBox="0 0 %g %g">`,
+		width, height)
+	fmt.Fprintf(b, `<rect x="%g" y="%g" width="%g" height="%g" rx="10" fill="#ffffff" stroke="#000000" stroke-width="%g"/>`,
+		border/2, border/2, width-border, height-border, border)
+
+	// the index is drawn in the top left corner and again, rotated, in the bottom right
+	index := &strings.Builder{}
+	glyphWidth := 10.0
+	if card == 10 {
+		glyphWidth = 13
+	}
+	scale := glyphHeight / 16
+	fmt.Fprintf(index, `<path d="%s" fill="none" stroke="%s" stroke-width="%g" stroke-linecap="round" `+
+		`stroke-linejoin="round" transform="matrix(%g 0 0 %g %g %g)"/>`,
+		glyphs[card-1], fill, stroke, scale, scale, 10.0, 10.0)
+	writePip(index, suit, fill, 10+glyphWidth*scale/2, 10+glyphHeight+pipSize*0.75, pipSize, false)
+	b.WriteString(index.String())
+	fmt.Fprintf(b, `<g transform="matrix(-1 0 0 -1 %g %g)">%s</g>`, width, height, index.String())
+
+	switch {
+	case s.LargeIndex:
+		writePip(b, suit, fill, width/2, height/2, 80, false)
+	case card > len(pipLayouts):
+		writeCourt(b, card, suit, fill, width, height)
+	default:
+		const left, top, right, bottom = 50.0, 42.0, 117.0, 200.0
+		for _, pos := range pipLayouts[card-1] {
+			x, y := left+pos[0]*(right-left), top+pos[1]*(bottom-top)
+			size := 30.0
+			if card == 1 {
+				size = 60
+			}
+			writePip(b, suit, fill, x, y, size, pos[1] > 0.5)
+		}
+	}
+
+	b.WriteString(`</svg>`)
+	return b.String()
+}
+
+// writeCourt draws a framed panel showing a large letter and suit in place of the court card artwork
+func writeCourt(b *strings.Builder, card, suit int, fill string, width, height float64) {
+	const inset = 38.0
+	fmt.Fprintf(b, `<rect x="%g" y="%g" width="%g" height="%g" rx="6" fill="none" stroke="%s" stroke-width="2"/>`,
+		inset, inset, width-inset*2, height-inset*2, fill)
+	scale := 4.0
+	fmt.Fprintf(b, `<path d="%s" fill="none" stroke="%s" stroke-width="1.5" stroke-linecap="round" `+
+		`stroke-linejoin="round" transform="matrix(%g 0 0 %g %g %g)"/>`,
+		glyphs[card-1], fill, scale, scale, width/2-5*scale, height/2-50)
+	writePip(b, suit, fill, width/2, height/2+45, 40, false)
+}
+
+// writePip draws the symbol for a suit centred on x, y, turned upside down if flip is true
+func writePip(b *strings.Builder, suit int, fill string, x, y, size float64, flip bool) {
+	scale := size / 100
+	if flip {
+		fmt.Fprintf(b, `<g fill="%s" transform="matrix(%g 0 0 %g %g %g)">%s</g>`,
+			fill, -scale, -scale, x+size/2, y+size/2, emblemShapes[suit+1])
+		return
+	}
+	fmt.Fprintf(b, `<g fill="%s" transform="matrix(%g 0 0 %g %g %g)">%s</g>`,
+		fill, scale, scale, x-size/2, y-size/2, emblemShapes[suit+1])
+}
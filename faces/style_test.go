@@ -0,0 +1,48 @@
+package faces
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltinPack(t *testing.T) {
+	assert.Equal(t, DefaultPack(), BuiltinPack(BuiltinPacks()[0]))
+	assert.Nil(t, BuiltinPack("Unknown"))
+
+	four := BuiltinPack(FourColourPack)
+	assert.NotNil(t, four)
+	assert.Same(t, four, BuiltinPack(FourColourPack))
+	assert.Equal(t, FourColourPack, four.Manifest.Name)
+}
+
+func TestNewStylePack_FourColour(t *testing.T) {
+	p := NewStylePack("Test", FaceStyle{Palette: PaletteFourColour})
+	names := make(map[string]bool)
+	for _, face := range p.faces {
+		assert.True(t, strings.HasPrefix(string(face.Content()), "<svg"))
+		names[face.Name()] = true
+	}
+	assert.Len(t, names, 52)
+
+	club, diamond := string(p.faces[0].Content()), string(p.faces[13].Content())
+	assert.Contains(t, club, `fill="#00802b"`)
+	assert.Contains(t, diamond, `fill="#0050d0"`)
+	assert.NotContains(t, diamond, "#d40000")
+}
+
+func TestNewStylePack_Names(t *testing.T) {
+	two := NewStylePack("Two", FaceStyle{})
+	large := NewStylePack("Large", FaceStyle{LargeIndex: true})
+	assert.NotEqual(t, two.faces[0].Name(), large.faces[0].Name())
+	assert.Contains(t, string(two.faces[13].Content()), `fill="#d40000"`)
+}
+
+func TestSetPack_Style(t *testing.T) {
+	SetPack(BuiltinPack(HighContrastPack))
+	defer SetPack(nil)
+
+	assert.Equal(t, BuiltinPack(HighContrastPack).faces[0], ForCard(1, 0))
+	assert.Equal(t, cardBackSvg, ForBack())
+}
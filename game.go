@@ -36,6 +36,16 @@ func (s *Stack) Pop() *Card {
 	return ret
 }
 
+// indexOf returns the position of the card in the stack, or -1 if it is not found
+func (s *Stack) indexOf(card *Card) int {
+	for i, c := range s.Cards {
+		if cardEquals(c, card) {
+			return i
+		}
+	}
+	return -1
+}
+
 // Contains will return true if the stack contains the specified card
 func (s *Stack) Contains(card *Card) bool {
 	for _, c := range s.Cards {
@@ -51,25 +61,58 @@ func (s *Stack) Contains(card *Card) bool {
 type Game struct {
 	Hand *Deck
 
+	// Draw1, Draw2 and Draw3 show the top (up to) three cards of the waste, filled from Draw1.
+	// The last of these that is not nil is the only playable waste card.
 	Draw1, Draw2, Draw3 *Card
 	Drawn               *Deck
 
-	Build1 *Stack
-	Build2 *Stack
-	Build3 *Stack
-	Build4 *Stack
-
-	Stack1 *Stack
-	Stack2 *Stack
-	Stack3 *Stack
-	Stack4 *Stack
-	Stack5 *Stack
-	Stack6 *Stack
-	Stack7 *Stack
+	Foundations []*Stack
+	Tableau     []*Stack
+
+	// MaxPasses is the number of times the stock can be drawn through, or PassesUnlimited.
+	// Redeals counts how many times the waste has been turned back into the stock.
+	MaxPasses int
+	Redeals   int
+
+	// Seed is the value used to shuffle the deck for this deal
+	Seed int64
+	// FixedStock stops the stock being shuffled, so a deal that was checked to be winnable,
+	// or that is shared as the daily deal, is played in the order it was dealt
+	FixedStock bool
+	// Moves counts the moves made, including draws from the stock
+	Moves int
+	// Timer starts counting when the first move is made and stops when the game is won
+	Timer *Timer
+	// AutoPlay moves cards that are safe to build to the foundations after every move
+	AutoPlay bool
 
 	OnWin func()
+	// OnEvent, if set, is called as things happen in the game so that the app can give feedback
+	OnEvent func(GameEvent)
+
+	autoPlaying bool
+	faceDown    int  // the number of face down tableau cards after the last move
+	recorded    bool // set once the game has been added to the statistics
 }
 
+// GameEvent describes something that happened in a game
+type GameEvent int
+
+const (
+	// EventDraw is sent when cards are drawn from the stock
+	EventDraw GameEvent = iota
+	// EventMove is sent when a card, or run of cards, is moved to a foundation or the tableau
+	EventMove
+	// EventFlip is sent when a face down tableau card is turned over
+	EventFlip
+	// EventRecycle is sent when the waste is turned over to become the stock again
+	EventRecycle
+	// EventIllegal is sent when a move is attempted that the rules do not allow
+	EventIllegal
+	// EventWin is sent when the last card is built on the foundations, before OnWin is called
+	EventWin
+)
+
 func pushToStack(s *Stack, d *Deck, count int) {
 	for i := 0; i < count; i++ {
 		card := d.Pop()
@@ -81,25 +124,24 @@ func pushToStack(s *Stack, d *Deck, count int) {
 }
 
 func (g *Game) deal() {
-	pushToStack(g.Stack1, g.Hand, 1)
-	pushToStack(g.Stack2, g.Hand, 2)
-	pushToStack(g.Stack3, g.Hand, 3)
-	pushToStack(g.Stack4, g.Hand, 4)
-	pushToStack(g.Stack5, g.Hand, 5)
-	pushToStack(g.Stack6, g.Hand, 6)
-	pushToStack(g.Stack7, g.Hand, 7)
+	for i, s := range g.Tableau {
+		pushToStack(s, g.Hand, i+1)
+	}
+	g.faceDown = g.countFaceDown()
 }
 
-// AutoBuild attempts to place the passed card onto one of the build stacks
-func (g *Game) AutoBuild(c *Card) {
-	for _, b := range []*Stack{g.Build1, g.Build2, g.Build3, g.Build4} {
+// AutoBuild attempts to place the passed card onto one of the build stacks, returning true if it was moved
+func (g *Game) AutoBuild(c *Card) bool {
+	for _, b := range g.Foundations {
 		if !g.ruleCanMoveToBuild(b, c) {
 			continue
 		}
 
+		moves := g.Moves
 		g.MoveCardToBuild(b, c)
-		break
+		return g.Moves != moves
 	}
+	return false
 }
 
 // ResetDraw resets the draw pile to be completely available (no cards drawn)
@@ -123,135 +165,243 @@ func (g *Game) drawCard() *Card {
 }
 
 // DrawThree draws three cards from the deck and adds them to the draw pile(s).
-// If there are no cards available to be drawn it will cycle back to the beginning and draw the first three.
+// If there are no cards available to be drawn it will cycle back to the beginning, unless the
+// MaxPasses limit has been reached, in which case nothing happens.
 func (g *Game) DrawThree() {
 	if len(g.Hand.Cards) == 0 {
-		g.Draw1 = nil
-		g.Draw2 = nil
-		g.Draw3 = nil
+		if len(g.Drawn.Cards) == 0 || !g.ruleCanRecycle() {
+			return
+		}
+		g.moved()
 
+		g.Redeals++
 		g.Hand = g.Drawn
 		for _, card := range g.Hand.Cards {
 			card.TurnFaceDown()
 		}
 		g.Drawn = &Deck{}
+		g.updateWaste()
+		g.event(EventRecycle)
+		g.afterMove()
+		return
+	}
+
+	g.moved()
+	g.drawCard()
+	g.drawCard()
+	g.drawCard()
+	g.updateWaste()
+	g.event(EventDraw)
+	g.afterMove()
+}
+
+// ShuffleStock reorders the cards remaining in the stock.
+// This is only allowed before any cards have been drawn in the current pass.
+func (g *Game) ShuffleStock() {
+	if !g.ruleCanShuffleStock() {
 		return
 	}
 
-	g.Draw1 = g.drawCard()
-	g.Draw2 = g.drawCard()
-	g.Draw3 = g.drawCard()
+	g.Hand.Shuffle()
+}
+
+// CanShuffleStock returns true if ShuffleStock would currently be allowed
+func (g *Game) CanShuffleStock() bool {
+	return g.ruleCanShuffleStock()
+}
+
+// StockExhausted returns true if the stock is empty and the waste cannot be recycled again
+func (g *Game) StockExhausted() bool {
+	return len(g.Hand.Cards) == 0 && !g.ruleCanRecycle()
+}
+
+// WasteTop returns the card on top of the waste pile, which is the only one that can be played.
+// If no cards have been drawn it will return nil.
+func (g *Game) WasteTop() *Card {
+	if len(g.Drawn.Cards) == 0 {
+		return nil
+	}
+
+	return g.Drawn.Cards[len(g.Drawn.Cards)-1]
+}
+
+// updateWaste sets the visible waste cards to be the most recently drawn cards that are still in play
+func (g *Game) updateWaste() {
+	shown := g.Drawn.Cards
+	if len(shown) > 3 {
+		shown = shown[len(shown)-3:]
+	}
+
+	slots := []**Card{&g.Draw1, &g.Draw2, &g.Draw3}
+	for i, slot := range slots {
+		if i < len(shown) {
+			*slot = shown[i]
+		} else {
+			*slot = nil
+		}
+	}
 }
 
 // MoveCardToBuild attempts to move the currently selected card to a build stack.
 // If the move is not possible it will return.
 func (g *Game) MoveCardToBuild(build *Stack, card *Card) {
 	if !g.ruleCanMoveToBuild(build, card) {
+		g.event(EventIllegal)
 		return
 	}
 
-	g.removeCard(card)
-	build.Push(card)
-
-	if len(g.Build1.Cards) == 13 && len(g.Build2.Cards) == 13 &&
-		len(g.Build3.Cards) == 13 && len(g.Build4.Cards) == 13 {
-
-		if g.OnWin != nil {
-			g.OnWin()
-		}
+	removed := g.removeCard(card)
+	if removed == nil {
+		g.event(EventIllegal)
+		return
 	}
+	build.Push(removed)
+	g.moved()
+	g.event(EventMove)
+	g.afterMove()
 }
 
 // MoveCardToStack attempts to move the currently selected card to a table stack.
 // If the move is not possible it will return.
 func (g *Game) MoveCardToStack(stack *Stack, card *Card) {
 	if !g.ruleCanMoveToStack(stack, card) {
+		g.event(EventIllegal)
 		return
 	}
 
 	oldStack := g.stackForCard(card)
 	if oldStack == nil {
-		g.removeCard(card)
-		stack.Push(card)
+		removed := g.removeCard(card)
+		if removed == nil {
+			g.event(EventIllegal)
+			return
+		}
+		stack.Push(removed)
+		g.moved()
+		g.event(EventMove)
+		g.afterMove()
 		return
 	}
 
-	found := false
-	for _, c := range oldStack.Cards {
-		if cardEquals(c, card) {
-			found = true
-		}
+	// only a face up card, and those above it, can be moved to another column
+	index := oldStack.indexOf(card)
+	if oldStack == stack || !oldStack.Cards[index].FaceUp {
+		g.event(EventIllegal)
+		return
+	}
 
-		if found {
-			// noinspection GoDeferInLoop
-			defer oldStack.Pop()
-			stack.Push(c)
-		}
+	stack.Cards = append(stack.Cards, oldStack.Cards[index:]...)
+	oldStack.Cards = oldStack.Cards[:index]
+	if top := oldStack.Top(); top != nil {
+		top.TurnFaceUp()
 	}
+	g.moved()
+	g.event(EventMove)
+	g.afterMove()
 }
 
-func (g *Game) stackForCard(card *Card) *Stack {
-	if g.Stack1.Contains(card) {
-		return g.Stack1
+// afterMove plays any safe cards to the foundations, if AutoPlay is set, then checks if the game has been won.
+// Cards played automatically each count as a move of their own.
+func (g *Game) afterMove() {
+	faceDown := g.countFaceDown()
+	if faceDown < g.faceDown {
+		g.event(EventFlip)
+	}
+	g.faceDown = faceDown
+	if g.autoPlaying {
+		return
 	}
-	if g.Stack2.Contains(card) {
-		return g.Stack2
+
+	if g.AutoPlay {
+		g.autoPlaying = true
+		for g.autoPlayOne() {
+		}
+		g.autoPlaying = false
 	}
-	if g.Stack3.Contains(card) {
-		return g.Stack3
+
+	for _, b := range g.Foundations {
+		if len(b.Cards) != ValueKing {
+			return
+		}
 	}
-	if g.Stack4.Contains(card) {
-		return g.Stack4
+
+	g.Timer.Stop()
+	g.event(EventWin)
+	if g.OnWin != nil {
+		g.OnWin()
 	}
-	if g.Stack5.Contains(card) {
-		return g.Stack5
+}
+
+// event tells the OnEvent listener, if there is one, that something happened
+func (g *Game) event(e GameEvent) {
+	if g.OnEvent != nil {
+		g.OnEvent(e)
 	}
-	if g.Stack6.Contains(card) {
-		return g.Stack6
+}
+
+func (g *Game) countFaceDown() int {
+	count := 0
+	for _, s := range g.Tableau {
+		for _, c := range s.Cards {
+			if !c.FaceUp {
+				count++
+			}
+		}
 	}
-	if g.Stack7.Contains(card) {
-		return g.Stack7
+	return count
+}
+
+// autoPlayOne moves a single card from the waste or tableau that is safe to build, returning false if there was none
+func (g *Game) autoPlayOne() bool {
+	candidates := []*Card{g.WasteTop()}
+	for _, s := range g.Tableau {
+		candidates = append(candidates, s.Top())
 	}
 
-	return nil
+	for _, c := range candidates {
+		if c != nil && c.FaceUp && g.ruleSafeToBuild(c) && g.AutoBuild(c) {
+			return true
+		}
+	}
+	return false
 }
 
-func (g *Game) removeCard(card *Card) {
-	if cardEquals(card, g.Draw3) {
-		g.Drawn.Remove(card)
-		g.Draw3 = nil
-	} else if cardEquals(card, g.Draw2) {
-		g.Drawn.Remove(card)
-		g.Draw2 = nil
-	} else if cardEquals(card, g.Draw1) {
-		// TODO what if it's empty - the previous draw?
-		g.Drawn.Remove(card)
-		g.Draw1 = nil
-
-	} else if cardEquals(card, g.Build1.Top()) {
-		g.Build1.Pop()
-	} else if cardEquals(card, g.Build2.Top()) {
-		g.Build2.Pop()
-	} else if cardEquals(card, g.Build3.Top()) {
-		g.Build3.Pop()
-	} else if cardEquals(card, g.Build4.Top()) {
-		g.Build4.Pop()
-
-	} else if cardEquals(card, g.Stack1.Top()) {
-		g.Stack1.Pop()
-	} else if cardEquals(card, g.Stack2.Top()) {
-		g.Stack2.Pop()
-	} else if cardEquals(card, g.Stack3.Top()) {
-		g.Stack3.Pop()
-	} else if cardEquals(card, g.Stack4.Top()) {
-		g.Stack4.Pop()
-	} else if cardEquals(card, g.Stack5.Top()) {
-		g.Stack5.Pop()
-	} else if cardEquals(card, g.Stack6.Top()) {
-		g.Stack6.Pop()
-	} else if cardEquals(card, g.Stack7.Top()) {
-		g.Stack7.Pop()
+// moved records that a move was made, starting the timer if it is the first
+func (g *Game) moved() {
+	g.Moves++
+	g.Timer.Start()
+}
+
+func (g *Game) stackForCard(card *Card) *Stack {
+	id := g.PileForCard(card)
+	if id.Type != PileTableau {
+		return nil
 	}
+
+	return g.Pile(id)
+}
+
+// removeCard takes a card off the top of the pile it is in, returning the card that was removed.
+// The card passed may be a copy, so callers should use the returned card, which is nil if it could not be removed.
+func (g *Game) removeCard(card *Card) *Card {
+	if top := g.WasteTop(); cardEquals(card, top) {
+		g.Drawn.Cards = g.Drawn.Cards[:len(g.Drawn.Cards)-1]
+		g.updateWaste()
+		return top
+	}
+
+	for _, b := range g.Foundations {
+		if cardEquals(card, b.Top()) {
+			return b.Pop()
+		}
+	}
+	for _, s := range g.Tableau {
+		if cardEquals(card, s.Top()) {
+			return s.Pop()
+		}
+	}
+
+	return nil
 }
 
 // NewGame starts a new solitaire game and draws to the standard configuration.
@@ -262,23 +412,20 @@ func NewGame() *Game {
 // NewGameFromSeed starts a new solitaire game and draws to the standard configuration.
 // The randomness of the desk is seeded using the specified value.
 func NewGameFromSeed(seed int64) *Game {
-	game := &Game{}
+	game := &Game{Seed: seed}
 	game.Hand = NewShuffledDeckFromSeed(seed)
 
 	game.Drawn = &Deck{}
+	game.Timer = &Timer{}
 
-	game.Stack1 = &Stack{}
-	game.Stack2 = &Stack{}
-	game.Stack3 = &Stack{}
-	game.Stack4 = &Stack{}
-	game.Stack5 = &Stack{}
-	game.Stack6 = &Stack{}
-	game.Stack7 = &Stack{}
-
-	game.Build1 = &Stack{}
-	game.Build2 = &Stack{}
-	game.Build3 = &Stack{}
-	game.Build4 = &Stack{}
+	game.Foundations = make([]*Stack, FoundationCount)
+	for i := range game.Foundations {
+		game.Foundations[i] = &Stack{}
+	}
+	game.Tableau = make([]*Stack, TableauCount)
+	for i := range game.Tableau {
+		game.Tableau[i] = &Stack{}
+	}
 
 	game.deal()
 	return game
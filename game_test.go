@@ -10,6 +10,18 @@ func newTestGame() *Game {
 	return NewGameFromSeed(0xace)
 }
 
+// newEmptyGame returns a game with no cards on the table, so tests can place exactly the cards they need
+func newEmptyGame() *Game {
+	g := newTestGame()
+	g.Hand = &Deck{}
+	g.Drawn = &Deck{}
+	g.updateWaste()
+	for _, s := range g.Tableau {
+		s.Cards = nil
+	}
+	return g
+}
+
 func TestStack_Push(t *testing.T) {
 	stack := &Stack{}
 	card := NewCard(1, SuitSpades)
@@ -22,19 +34,19 @@ func TestStack_Push(t *testing.T) {
 func TestGame_Deal(t *testing.T) {
 	game := newTestGame()
 
-	assert.Equal(t, 1, len(game.Stack1.Cards))
-	assert.Equal(t, 2, len(game.Stack2.Cards))
-	assert.Equal(t, 7, len(game.Stack7.Cards))
+	assert.Equal(t, 1, len(game.Tableau[0].Cards))
+	assert.Equal(t, 2, len(game.Tableau[1].Cards))
+	assert.Equal(t, 7, len(game.Tableau[6].Cards))
 	assert.Equal(t, 24, len(game.Hand.Cards))
 }
 
 func TestGame_Deal_FaceUp(t *testing.T) {
 	game := newTestGame()
 
-	assert.Equal(t, true, game.Stack1.Cards[0].FaceUp)
+	assert.Equal(t, true, game.Tableau[0].Cards[0].FaceUp)
 
-	assert.Equal(t, false, game.Stack2.Cards[0].FaceUp)
-	assert.Equal(t, true, game.Stack2.Cards[1].FaceUp)
+	assert.Equal(t, false, game.Tableau[1].Cards[0].FaceUp)
+	assert.Equal(t, true, game.Tableau[1].Cards[1].FaceUp)
 }
 
 func TestGame_Draw(t *testing.T) {
@@ -116,89 +128,332 @@ func TestGame_MoveCardToBuildFromHand(t *testing.T) {
 	game.DrawThree()
 	game.Draw3.Value = 1
 
-	game.MoveCardToBuild(game.Build1, game.Draw3)
-	assert.Equal(t, 1, len(game.Build1.Cards))
+	game.MoveCardToBuild(game.Foundations[0], game.Draw3)
+	assert.Equal(t, 1, len(game.Foundations[0].Cards))
 	assert.Nil(t, game.Draw3)
 }
 
 func TestGame_MoveCardToBuildFromStack2(t *testing.T) {
 	game := newTestGame()
-	game.Stack2.Cards[1].Value = 1
+	game.Tableau[1].Cards[1].Value = 1
 
-	assert.Equal(t, 2, len(game.Stack2.Cards))
-	assert.False(t, game.Stack2.Cards[0].FaceUp)
+	assert.Equal(t, 2, len(game.Tableau[1].Cards))
+	assert.False(t, game.Tableau[1].Cards[0].FaceUp)
 
-	game.MoveCardToBuild(game.Build2, game.Stack2.Cards[1])
-	assert.Equal(t, 1, len(game.Build2.Cards))
-	assert.Equal(t, 1, len(game.Stack2.Cards))
-	assert.True(t, game.Stack2.Cards[0].FaceUp)
+	game.MoveCardToBuild(game.Foundations[1], game.Tableau[1].Cards[1])
+	assert.Equal(t, 1, len(game.Foundations[1].Cards))
+	assert.Equal(t, 1, len(game.Tableau[1].Cards))
+	assert.True(t, game.Tableau[1].Cards[0].FaceUp)
 }
 
 func TestGame_MoveCardToStack(t *testing.T) {
 	game := newTestGame()
 
-	game.Stack1.Cards[0].Value = 3
-	game.Stack1.Cards[0].Suit = SuitClubs
-	game.Stack2.Cards[1].Value = 2
-	game.Stack2.Cards[1].Suit = SuitDiamonds
-	assert.False(t, game.Stack2.Cards[0].FaceUp)
+	game.Tableau[0].Cards[0].Value = 3
+	game.Tableau[0].Cards[0].Suit = SuitClubs
+	game.Tableau[1].Cards[1].Value = 2
+	game.Tableau[1].Cards[1].Suit = SuitDiamonds
+	assert.False(t, game.Tableau[1].Cards[0].FaceUp)
 
-	game.MoveCardToStack(game.Stack1, game.Stack2.Cards[1])
-	assert.Equal(t, 2, len(game.Stack1.Cards))
-	assert.Equal(t, 1, len(game.Stack2.Cards))
-	assert.True(t, game.Stack2.Cards[0].FaceUp)
+	game.MoveCardToStack(game.Tableau[0], game.Tableau[1].Cards[1])
+	assert.Equal(t, 2, len(game.Tableau[0].Cards))
+	assert.Equal(t, 1, len(game.Tableau[1].Cards))
+	assert.True(t, game.Tableau[1].Cards[0].FaceUp)
 }
 
 func TestGame_MoveCardToStack_Empty(t *testing.T) {
 	game := newTestGame()
 
-	game.Stack1.Cards = []*Card{}
-	game.Stack2.Cards[1].Value = ValueKing
-	game.Stack2.Cards[1].Suit = SuitDiamonds
+	game.Tableau[0].Cards = []*Card{}
+	game.Tableau[1].Cards[1].Value = ValueKing
+	game.Tableau[1].Cards[1].Suit = SuitDiamonds
 
-	game.MoveCardToStack(game.Stack1, game.Stack2.Cards[1])
-	assert.Equal(t, 1, len(game.Stack1.Cards))
-	assert.Equal(t, 1, len(game.Stack2.Cards))
-	assert.True(t, game.Stack2.Cards[0].FaceUp)
+	game.MoveCardToStack(game.Tableau[0], game.Tableau[1].Cards[1])
+	assert.Equal(t, 1, len(game.Tableau[0].Cards))
+	assert.Equal(t, 1, len(game.Tableau[1].Cards))
+	assert.True(t, game.Tableau[1].Cards[0].FaceUp)
 }
 
 func TestGame_MoveCardToStack_EmptyEmpty(t *testing.T) {
 	game := newTestGame()
 
-	game.Stack3.Cards = []*Card{}
+	game.Tableau[2].Cards = []*Card{}
 	king := NewCard(ValueKing, SuitDiamonds)
-	game.Stack2.Cards = []*Card{king}
+	king.TurnFaceUp()
+	game.Tableau[1].Cards = []*Card{king}
 
-	game.MoveCardToStack(game.Stack3, game.Stack2.Cards[0])
-	assert.Equal(t, 1, len(game.Stack3.Cards))
-	assert.Equal(t, 0, len(game.Stack2.Cards))
+	game.MoveCardToStack(game.Tableau[2], game.Tableau[1].Cards[0])
+	assert.Equal(t, 1, len(game.Tableau[2].Cards))
+	assert.Equal(t, 0, len(game.Tableau[1].Cards))
 }
 
 func TestGame_MoveCardToStack_Stack(t *testing.T) {
 	game := newTestGame()
 
-	game.Stack1.Cards[0].Value = 7
-	game.Stack1.Cards[0].Suit = SuitClubs
-	game.Stack2.Cards[0].Value = 6
-	game.Stack2.Cards[0].Suit = SuitDiamonds
-	game.Stack2.Cards[1].Value = 5
-	game.Stack2.Cards[1].Suit = SuitSpades
-
-	game.MoveCardToStack(game.Stack1, game.Stack2.Cards[0])
-	assert.Equal(t, 3, len(game.Stack1.Cards))
-	assert.Equal(t, 0, len(game.Stack2.Cards))
+	game.Tableau[0].Cards[0].Value = 7
+	game.Tableau[0].Cards[0].Suit = SuitClubs
+	game.Tableau[1].Cards[0].Value = 6
+	game.Tableau[1].Cards[0].Suit = SuitDiamonds
+	game.Tableau[1].Cards[1].Value = 5
+	game.Tableau[1].Cards[1].Suit = SuitSpades
+	game.Tableau[1].Cards[0].TurnFaceUp()
+
+	game.MoveCardToStack(game.Tableau[0], game.Tableau[1].Cards[0])
+	assert.Equal(t, 3, len(game.Tableau[0].Cards))
+	assert.Equal(t, 0, len(game.Tableau[1].Cards))
 }
 
 func TestGame_MoveCardToStack_KingStack(t *testing.T) {
 	game := newTestGame()
 
-	game.Stack1.Cards = []*Card{}
-	game.Stack3.Cards[1].Value = ValueKing
-	game.Stack3.Cards[1].Suit = SuitDiamonds
-	game.Stack3.Cards[2].Value = ValueQueen
-	game.Stack3.Cards[2].Suit = SuitSpades
+	game.Tableau[0].Cards = []*Card{}
+	game.Tableau[2].Cards[1].Value = ValueKing
+	game.Tableau[2].Cards[1].Suit = SuitDiamonds
+	game.Tableau[2].Cards[2].Value = ValueQueen
+	game.Tableau[2].Cards[2].Suit = SuitSpades
+	game.Tableau[2].Cards[1].TurnFaceUp()
+
+	game.MoveCardToStack(game.Tableau[0], game.Tableau[2].Cards[1])
+	assert.Equal(t, 2, len(game.Tableau[0].Cards))
+	assert.Equal(t, 1, len(game.Tableau[2].Cards))
+}
+
+func TestGame_WastePlayPrevious(t *testing.T) {
+	game := newTestGame()
+	game.DrawThree()
+	first := game.Draw3
+	game.DrawThree()
+	assert.Equal(t, 6, len(game.Drawn.Cards))
+
+	game.Drawn.Remove(game.Draw3)
+	game.updateWaste()
+	game.Drawn.Remove(game.Draw3)
+	game.updateWaste()
+	assert.NotNil(t, game.Draw3)
+
+	// the last of the second draw is now on top of the first draw's cards
+	game.Drawn.Remove(game.Draw3)
+	game.updateWaste()
+	assert.Equal(t, first, game.Draw3)
+	assert.Equal(t, first, game.WasteTop())
+}
+
+func TestGame_WasteStart(t *testing.T) {
+	game := newTestGame()
+	assert.Nil(t, game.WasteTop())
+	assert.Nil(t, game.Draw1)
+
+	game.DrawThree()
+	game.Draw3.Value = 1
+	game.Draw3.Suit = SuitHearts
+	game.Draw2.Value = 1
+	game.Draw2.Suit = SuitClubs
+	game.Draw1.Value = 1
+	game.Draw1.Suit = SuitSpades
+
+	game.MoveCardToBuild(game.Foundations[0], game.Draw1) // not on top, can't be played
+	assert.Equal(t, 3, len(game.Drawn.Cards))
+
+	game.MoveCardToBuild(game.Foundations[0], game.Draw3)
+	game.MoveCardToBuild(game.Foundations[1], game.Draw2)
+	assert.NotNil(t, game.Draw1)
+	assert.Nil(t, game.Draw2)
+
+	game.MoveCardToBuild(game.Foundations[2], game.Draw1)
+	assert.Nil(t, game.Draw1)
+	assert.Nil(t, game.WasteTop())
+	assert.Equal(t, 0, len(game.Drawn.Cards))
+}
+
+func TestGame_WasteEndOfStock(t *testing.T) {
+	game := newTestGame()
+	game.Hand.Cards = game.Hand.Cards[:4]
+
+	game.DrawThree()
+	game.DrawThree()
+	assert.Equal(t, 0, len(game.Hand.Cards))
+	assert.Equal(t, 4, len(game.Drawn.Cards))
+
+	// a single card was drawn but the previous two are still shown beneath it
+	assert.Equal(t, game.Drawn.Cards[1], game.Draw1)
+	assert.Equal(t, game.Drawn.Cards[2], game.Draw2)
+	assert.Equal(t, game.Drawn.Cards[3], game.Draw3)
+	assert.Equal(t, game.Drawn.Cards[3], game.WasteTop())
+
+	game.DrawThree()
+	assert.Equal(t, 4, len(game.Hand.Cards))
+	assert.Nil(t, game.Draw1)
+	assert.Nil(t, game.WasteTop())
+}
+
+func TestGame_DrawPassLimit(t *testing.T) {
+	game := newTestGame()
+	game.MaxPasses = PassesOne
+
+	for i := 0; i < 8; i++ {
+		game.DrawThree()
+	}
+	assert.True(t, game.StockExhausted())
+
+	game.DrawThree()
+	assert.Equal(t, 0, len(game.Hand.Cards))
+	assert.Equal(t, 24, len(game.Drawn.Cards))
+	assert.Equal(t, 0, game.Redeals)
+}
+
+func TestGame_DrawPassLimitThree(t *testing.T) {
+	game := newTestGame()
+	game.MaxPasses = PassesThree
+
+	game.ResetDraw()
+	game.ResetDraw()
+	assert.Equal(t, 2, game.Redeals)
+	assert.False(t, game.StockExhausted())
+
+	for i := 0; i < 8; i++ {
+		game.DrawThree()
+	}
+	assert.True(t, game.StockExhausted())
+}
+
+func TestGame_DrawEmptyStock(t *testing.T) {
+	game := newEmptyGame()
+	events := 0
+	game.OnEvent = func(GameEvent) {
+		events++
+	}
+
+	game.DrawThree()
+	assert.Equal(t, 0, game.Redeals)
+	assert.Equal(t, 0, game.Moves)
+	assert.Zero(t, events)
+}
+
+func TestGame_ShuffleStock(t *testing.T) {
+	game := newTestGame()
+	assert.True(t, game.CanShuffleStock())
+
+	game.DrawThree()
+	top := game.Hand.Cards[0]
+	game.ShuffleStock()
+	assert.Equal(t, top, game.Hand.Cards[0])
+	assert.False(t, game.CanShuffleStock())
+}
+
+func TestGame_MoveCardToStack_FromFoundation(t *testing.T) {
+	game := newEmptyGame()
+	ace := &Card{Value: 1, Suit: SuitHearts, FaceUp: true}
+	two := &Card{Value: 2, Suit: SuitHearts, FaceUp: true}
+	three := &Card{Value: 3, Suit: SuitSpades, FaceUp: true}
+	game.Foundations[0].Cards = []*Card{ace, two}
+	game.Tableau[0].Cards = []*Card{three}
+
+	game.MoveCardToStack(game.Tableau[0], two)
+	assert.Equal(t, []*Card{ace}, game.Foundations[0].Cards)
+	assert.Equal(t, []*Card{three, two}, game.Tableau[0].Cards)
+	assert.Equal(t, 1, game.Moves)
+}
+
+func TestGame_MoveCardToStack_FromFoundationInvalid(t *testing.T) {
+	game := newEmptyGame()
+	ace := &Card{Value: 1, Suit: SuitHearts, FaceUp: true}
+	two := &Card{Value: 2, Suit: SuitHearts, FaceUp: true}
+	three := &Card{Value: 3, Suit: SuitDiamonds, FaceUp: true}
+	game.Foundations[0].Cards = []*Card{ace, two}
+	game.Tableau[0].Cards = []*Card{three}
+
+	game.MoveCardToStack(game.Tableau[0], two)
+	assert.Equal(t, []*Card{ace, two}, game.Foundations[0].Cards)
+	assert.Equal(t, []*Card{three}, game.Tableau[0].Cards)
+
+	game.MoveCardToStack(game.Tableau[0], ace)
+	assert.Equal(t, []*Card{ace, two}, game.Foundations[0].Cards)
+	assert.Equal(t, 0, game.Moves)
+}
+
+func TestGame_AutoPlay(t *testing.T) {
+	game := newEmptyGame()
+	game.AutoPlay = true
+	aceClubs := &Card{Value: 1, Suit: SuitClubs, FaceUp: true}
+	twoClubs := &Card{Value: 2, Suit: SuitClubs, FaceUp: true}
+	threeClubs := &Card{Value: 3, Suit: SuitClubs, FaceUp: true}
+	fourHearts := &Card{Value: 4, Suit: SuitHearts, FaceUp: true}
+	game.Tableau[0].Cards = []*Card{threeClubs, twoClubs}
+	game.Tableau[1].Cards = []*Card{aceClubs}
+	game.Tableau[2].Cards = []*Card{{Value: 5, Suit: SuitSpades, FaceUp: true}}
+	game.Tableau[3].Cards = []*Card{fourHearts}
+
+	game.MoveCardToStack(game.Tableau[2], fourHearts)
+	// the ace and two are always safe, the three is not until the red twos are built
+	assert.Equal(t, []*Card{aceClubs, twoClubs}, game.Foundations[0].Cards)
+	assert.Equal(t, []*Card{threeClubs}, game.Tableau[0].Cards)
+	assert.Equal(t, 3, game.Moves)
+}
+
+func TestGame_AutoPlay_Off(t *testing.T) {
+	game := newEmptyGame()
+	ace := &Card{Value: 1, Suit: SuitClubs, FaceUp: true}
+	game.Tableau[1].Cards = []*Card{ace}
+	game.Hand.Push(&Card{Value: 5, Suit: SuitSpades})
+
+	game.DrawThree()
+	assert.Equal(t, []*Card{ace}, game.Tableau[1].Cards)
+
+	game.AutoPlay = true
+	game.DrawThree()
+	assert.Equal(t, 0, len(game.Tableau[1].Cards))
+}
+
+func TestGame_AutoPlay_Win(t *testing.T) {
+	game := newNearlyWonGame()
+	game.AutoPlay = true
+	wins := 0
+	game.OnWin = func() {
+		wins++
+	}
+
+	king := game.Tableau[0].Top()
+	game.MoveCardToBuild(game.Foundations[king.Suit], king)
+	assert.Equal(t, 1, wins)
+	for _, b := range game.Foundations {
+		assert.Equal(t, ValueKing, len(b.Cards))
+	}
+}
+
+func TestGame_OnEvent(t *testing.T) {
+	game := newTestGame()
+	var events []GameEvent
+	game.OnEvent = func(e GameEvent) {
+		events = append(events, e)
+	}
+
+	game.DrawThree()
+	assert.Equal(t, []GameEvent{EventDraw}, events)
+
+	game.MoveCardToBuild(game.Foundations[0], game.Tableau[6].Top())
+	assert.Equal(t, EventIllegal, events[len(events)-1])
+
+	events = nil
+	for len(game.Hand.Cards) > 0 {
+		game.DrawThree()
+	}
+	events = nil
+	game.DrawThree()
+	assert.Equal(t, []GameEvent{EventRecycle}, events)
+}
 
-	game.MoveCardToStack(game.Stack1, game.Stack3.Cards[1])
-	assert.Equal(t, 2, len(game.Stack1.Cards))
-	assert.Equal(t, 1, len(game.Stack3.Cards))
+func TestGame_OnEvent_Flip(t *testing.T) {
+	game := newEmptyGame()
+	hidden := &Card{Value: 9, Suit: SuitSpades}
+	four := &Card{Value: 4, Suit: SuitHearts, FaceUp: true}
+	game.Tableau[0].Cards = []*Card{hidden, four}
+	game.Tableau[1].Cards = []*Card{{Value: 5, Suit: SuitClubs, FaceUp: true}}
+	game.faceDown = game.countFaceDown()
+	var events []GameEvent
+	game.OnEvent = func(e GameEvent) {
+		events = append(events, e)
+	}
+
+	game.MoveCardToStack(game.Tableau[1], four)
+	assert.Equal(t, []GameEvent{EventMove, EventFlip}, events)
 }
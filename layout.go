@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+)
+
+// maxStackCards is the most cards that a tableau column can hold, six face down and a run from king to ace
+const maxStackCards = 19
+
+// tableLayout is the geometry of a table, used to position the cards and to find what is under a tap or drag.
+// It belongs to the Table so that hit testing does not depend on the renderer,
+// and each table has its own so that tables of different sizes can be open at once.
+type tableLayout struct {
+	size     fyne.Size
+	cardSize fyne.Size
+	pad      float32 // the space between columns
+	overlap  float32 // how far the visible waste cards are fanned
+	fan      float32 // the most space shown of each face up card in a column, as a fraction of the card height
+	left     float32 // the space to the side of the columns when the cards are sized by height
+	mirrored bool    // true when laid out for left handed play
+	sepY     float32 // the position of the separator between the top row and the tableau
+	stackY   float32 // the top of the tableau columns
+}
+
+// newTableLayout returns the layout of a table at its minimum card size, until it is resized
+func newTableLayout() *tableLayout {
+	return &tableLayout{cardSize: fyne.NewSize(minCardWidth, minCardWidth*cardRatio),
+		pad: minPadding, overlap: minPadding * 5, fan: maxFan}
+}
+
+// cardHit is a card, or an empty pile, found on the table.
+// For the waste the index is which of the three visible cards was hit.
+type cardHit struct {
+	pile  PileID
+	index int // -1 if the pile is empty
+}
+
+// resize calculates the card size and the positions of the piles for a table of the given size.
+// In portrait the cards are limited by the width, so the height left over is used to fan the columns further.
+func (l *tableLayout) resize(size fyne.Size, mirrored bool) {
+	l.pad = size.Width * .006
+	l.overlap = l.pad * 5
+	sepThick := theme.SeparatorThicknessSize()
+
+	// cards are sized to fit the width, unless that would not leave room below the top row for a fanned column
+	newWidth := (size.Width - l.pad*6) / 7.0
+	if fitHeight := (size.Height - l.pad*2 - sepThick) / (cardRatio * minTableauRows); fitHeight < newWidth {
+		newWidth = fitHeight
+	}
+	l.cardSize = fyne.NewSize(newWidth, newWidth*cardRatio)
+	l.fan = maxFan
+	if size.Height > size.Width {
+		l.fan = portraitFan
+	}
+
+	l.size = size
+	l.left = (size.Width - (l.cardSize.Width*7 + l.pad*6)) / 2
+	l.mirrored = mirrored
+	l.sepY = l.cardSize.Height + l.pad
+	l.stackY = l.pad*2 + sepThick + l.cardSize.Height
+}
+
+// columnX returns the horizontal position of one of the seven columns that the table is laid out in
+func (l *tableLayout) columnX(i int) float32 {
+	return l.left + (l.pad+l.cardSize.Width)*float32(i)
+}
+
+// topRowX returns the horizontal position of a column in the top row, which is reversed when mirrored
+func (l *tableLayout) topRowX(i int) float32 {
+	if l.mirrored {
+		i = TableauCount - 1 - i
+	}
+	return l.columnX(i)
+}
+
+// stockPos returns the position of the stock, in the first column of the top row
+func (l *tableLayout) stockPos() fyne.Position {
+	return fyne.NewPos(l.topRowX(0), 0)
+}
+
+// wastePos returns the position of one of the three visible waste cards, which fan away from the stock
+func (l *tableLayout) wastePos(i int) fyne.Position {
+	fan := l.overlap
+	if l.mirrored {
+		fan = -l.overlap
+	}
+	return fyne.NewPos(l.topRowX(1)+fan*float32(i), 0)
+}
+
+// foundationPos returns the position of a foundation, these fill the end of the top row
+func (l *tableLayout) foundationPos(i int) fyne.Position {
+	return fyne.NewPos(l.topRowX(TableauCount-FoundationCount+i), 0)
+}
+
+// columnPos returns the position of the top of a tableau column
+func (l *tableLayout) columnPos(i int) fyne.Position {
+	return fyne.NewPos(l.columnX(i), l.stackY)
+}
+
+// columnOffsets returns the distance from the top of a column to each of its card slots
+func (l *tableLayout) columnOffsets(stack *Stack) []float32 {
+	var cards []*Card
+	if stack != nil {
+		cards = stack.Cards
+	}
+	return fanOffsets(cards, maxStackCards, l.cardSize.Height, l.fan, l.size.Height-l.stackY)
+}
+
+// cardPos returns the position of the card hit, or of the space for an empty pile
+func (l *tableLayout) cardPos(g *Game, h cardHit) fyne.Position {
+	switch h.pile.Type {
+	case PileStock:
+		return l.stockPos()
+	case PileWaste:
+		return l.wastePos(h.index)
+	case PileFoundation:
+		return l.foundationPos(h.pile.Index)
+	case PileTableau:
+		pos := l.columnPos(h.pile.Index)
+		if h.index <= 0 {
+			return pos
+		}
+		return pos.AddXY(0, l.columnOffsets(g.Tableau[h.pile.Index])[h.index])
+	}
+	return fyne.Position{}
+}
+
+// pilePos returns the position of the top card of a foundation or tableau pile, or of its space if empty
+func (l *tableLayout) pilePos(g *Game, id PileID) fyne.Position {
+	top := -1
+	if stack := g.Pile(id); stack != nil {
+		top = len(stack.Cards) - 1
+	}
+	return l.cardPos(g, cardHit{pile: id, index: top})
+}
+
+// hit returns the card, or empty pile, at a position on the table.
+// Only the top card of the waste and foundations can be hit, and the tableau is searched from the top card down.
+func (l *tableLayout) hit(g *Game, pos fyne.Position) (cardHit, bool) {
+	if l.withinCard(l.stockPos(), pos) {
+		return cardHit{pile: StockPile}, true
+	}
+
+	waste := -1
+	for i, c := range []*Card{g.Draw1, g.Draw2, g.Draw3} {
+		if c != nil {
+			waste = i
+		}
+	}
+	if waste >= 0 && l.withinCard(l.wastePos(waste), pos) {
+		return cardHit{pile: WastePile, index: waste}, true
+	}
+
+	for i, b := range g.Foundations {
+		if l.withinCard(l.foundationPos(i), pos) {
+			return cardHit{pile: FoundationPile(i), index: len(b.Cards) - 1}, true
+		}
+	}
+
+	for i, s := range g.Tableau {
+		id := TableauPile(i)
+		for j := len(s.Cards) - 1; j >= 0; j-- {
+			if h := (cardHit{pile: id, index: j}); l.withinCard(l.cardPos(g, h), pos) {
+				return h, true
+			}
+		}
+		if len(s.Cards) == 0 && l.withinCard(l.columnPos(i), pos) {
+			return cardHit{pile: id, index: -1}, true
+		}
+	}
+
+	return cardHit{}, false
+}
+
+// cards returns the card hit and any that are on top of it, which move with it
+func (h cardHit) cards(g *Game) []*Card {
+	if h.index < 0 {
+		return nil
+	}
+
+	switch h.pile.Type {
+	case PileWaste:
+		return []*Card{[]*Card{g.Draw1, g.Draw2, g.Draw3}[h.index]}
+	case PileFoundation, PileTableau:
+		stack := g.Pile(h.pile)
+		if h.index < len(stack.Cards) {
+			return stack.Cards[h.index:]
+		}
+	}
+	return nil
+}
+
+// placeCard sizes a card image for this layout and moves it to pos
+func (l *tableLayout) placeCard(c *canvas.Image, pos fyne.Position) {
+	c.Resize(l.cardSize)
+	c.Move(pos)
+}
+
+// withinCard returns true if pos is over a card drawn at cardPos
+func (l *tableLayout) withinCard(cardPos, pos fyne.Position) bool {
+	return pos.X >= cardPos.X && pos.Y >= cardPos.Y &&
+		pos.X < cardPos.X+l.cardSize.Width && pos.Y < cardPos.Y+l.cardSize.Height
+}
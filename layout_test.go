@@ -0,0 +1,90 @@
+package main
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fyne-io/solitaire/faces"
+)
+
+func newTestLayout() *tableLayout {
+	l := &tableLayout{}
+	l.resize(fyne.NewSize(700, 500), false)
+	return l
+}
+
+func TestTableLayout_HitTableau(t *testing.T) {
+	g := NewGameFromSeed(1)
+	l := newTestLayout()
+
+	top := l.cardPos(g, cardHit{pile: TableauPile(6), index: 6})
+	h, ok := l.hit(g, top.AddXY(2, 2))
+	assert.True(t, ok)
+	assert.Equal(t, cardHit{pile: TableauPile(6), index: 6}, h)
+	assert.Equal(t, g.Tableau[6].Cards[6:], h.cards(g))
+
+	h, ok = l.hit(g, l.cardPos(g, cardHit{pile: TableauPile(6), index: 2}).AddXY(2, 1))
+	assert.True(t, ok)
+	assert.Equal(t, g.Tableau[6].Cards[2:], h.cards(g))
+}
+
+func TestTableLayout_HitEmpty(t *testing.T) {
+	g := newEmptyGame()
+	l := newTestLayout()
+
+	h, ok := l.hit(g, l.columnPos(3).AddXY(2, 2))
+	assert.True(t, ok)
+	assert.Equal(t, cardHit{pile: TableauPile(3), index: -1}, h)
+	assert.Nil(t, h.cards(g))
+
+	h, ok = l.hit(g, l.foundationPos(1).AddXY(2, 2))
+	assert.True(t, ok)
+	assert.Equal(t, cardHit{pile: FoundationPile(1), index: -1}, h)
+
+	_, ok = l.hit(g, fyne.NewPos(l.columnX(3)+2, l.sepY-1))
+	assert.False(t, ok)
+}
+
+func TestTableLayout_HitTopRow(t *testing.T) {
+	g := NewGameFromSeed(1)
+	l := newTestLayout()
+
+	h, ok := l.hit(g, l.stockPos().AddXY(2, 2))
+	assert.True(t, ok)
+	assert.Equal(t, StockPile, h.pile)
+
+	_, ok = l.hit(g, l.wastePos(0).AddXY(2, 2))
+	assert.False(t, ok)
+	g.DrawThree()
+	h, ok = l.hit(g, l.wastePos(2).AddXY(2, 2))
+	assert.True(t, ok)
+	assert.Equal(t, cardHit{pile: WastePile, index: 2}, h)
+	assert.Equal(t, []*Card{g.Draw3}, h.cards(g))
+}
+
+func TestTableLayout_PilePos(t *testing.T) {
+	g := NewGameFromSeed(1)
+	l := newTestLayout()
+
+	assert.Equal(t, l.foundationPos(2), l.pilePos(g, FoundationPile(2)))
+	assert.Equal(t, l.cardPos(g, cardHit{pile: TableauPile(4), index: 4}), l.pilePos(g, TableauPile(4)))
+	assert.Greater(t, l.pilePos(g, TableauPile(4)).Y, l.columnPos(4).Y)
+}
+
+func TestTable_RestartSwapsGame(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+
+	table := NewTable(NewGameFromSeed(1))
+	table.Resize(fyne.NewSize(700, 500))
+	render := test.WidgetRenderer(table).(*tableRender)
+
+	g := newEmptyGame()
+	table.setGame(g, "")
+	assert.Equal(t, g, table.game)
+	assert.Equal(t, faces.ForSpace(), render.stacks[0].cards[0].Resource)
+	assert.True(t, render.stacks[0].cards[1].Hidden)
+}
@@ -8,45 +8,110 @@ import (
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
-	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/widget"
 )
 
-// show creates a new game and loads a table rendered in a new window.
-func show(app fyne.App) {
-	game := NewGame()
-	table := NewTable(game)
+const (
+	prefPasses     = "passes"
+	prefWinnable   = "winnable"
+	prefLeftHanded = "lefthanded"
+	prefSmartTap   = "smarttap"
+	prefAutoPlay   = "autoplay"
+)
+
+var (
+	passesNames  = []string{"Unlimited", "3 passes", "1 pass (Vegas)"}
+	passesValues = []int{PassesUnlimited, PassesThree, PassesOne}
+)
+
+// toolbarLabel allows a label to be shown within a toolbar
+type toolbarLabel struct {
+	*widget.Label
+}
+
+func (t *toolbarLabel) ToolbarObject() fyne.CanvasObject {
+	return t.Label
+}
 
-	w := app.NewWindow("Solitaire")
-	shuffle := widget.NewToolbarAction(theme.ViewRefreshIcon(), func() {
-		table.game.Hand.Shuffle()
+// showWin tells the player that they won, offering to replay the celebration before starting a new game
+func showWin(t *Table, msg string, w fyne.Window) {
+	var d dialog.Dialog
+	replay := widget.NewButton("Replay Celebration", func() {
+		d.Hide()
+		t.celebrate(func() {
+			showWin(t, msg, w)
+		})
 	})
-	table.shuffle = shuffle
-	bar := widget.NewToolbar(
-		widget.NewToolbarAction(theme.ContentAddIcon(), func() {
-			checkRestart(table, w)
-		}),
-		shuffle)
-	w.SetContent(container.NewBorder(bar, nil, nil, nil, table))
-	w.Resize(fyne.NewSize(minWidth, minHeight))
-
-	game.OnWin = func() {
-		table.finishAnimation()
-		d := dialog.NewInformation("You Win!", "Congratulations", w)
-		d.SetOnClosed(table.Restart)
-		d.Show()
-	}
-	w.Show()
+	d = dialog.NewCustomWithoutButtons("You Win!", container.NewVBox(widget.NewLabel(msg),
+		container.NewHBox(layout.NewSpacer(), replay, widget.NewButton("New Game", func() {
+			d.Hide()
+			t.Restart()
+		}), layout.NewSpacer())), w)
+	d.Show()
 }
 
 func checkRestart(t *Table, w fyne.Window) {
-	dialog.ShowConfirm("New Game", "Start a new game?", func(ok bool) {
+	prefs := fyne.CurrentApp().Preferences()
+	passes := widget.NewSelect(passesNames, nil)
+	current := prefs.IntWithFallback(prefPasses, PassesUnlimited)
+	for i, v := range passesValues {
+		if v == current {
+			passes.SetSelectedIndex(i)
+		}
+	}
+
+	winnable := widget.NewCheck("Winnable deals only", nil)
+	winnable.SetChecked(prefs.Bool(prefWinnable))
+
+	var d dialog.Dialog
+	copyDeal := widget.NewButton("Copy Deal", func() {
+		fyne.CurrentApp().Clipboard().SetContent(t.game.FormatDeal())
+	})
+	enterDeal := widget.NewButton("Enter Deal...", func() {
+		d.Hide()
+		showEnterDeal(t, w)
+	})
+
+	content := container.NewVBox(widget.NewLabel("Start a new game?"),
+		widget.NewForm(widget.NewFormItem("Stock", passes)), winnable,
+		container.NewGridWithColumns(2, copyDeal, enterDeal))
+	d = dialog.NewCustomConfirm("New Game", "Start", "Cancel", content, func(ok bool) {
 		if !ok {
 			return
 		}
 
+		if i := passes.SelectedIndex(); i >= 0 {
+			prefs.SetInt(prefPasses, passesValues[i])
+		}
+		prefs.SetBool(prefWinnable, winnable.Checked)
 		t.Restart()
 	}, w)
+	d.Show()
+}
+
+// showEnterDeal asks for a position in the deal notation, such as one copied from another game, and plays it
+func showEnterDeal(t *Table, w fyne.Window) {
+	entry := widget.NewMultiLineEntry()
+	entry.SetPlaceHolder("Stock: 7C 2D QS ...\nTableau 1: KD\nTableau 2: #4S 8H\n...")
+	entry.SetMinRowsVisible(12)
+	entry.Validator = func(text string) error {
+		_, err := ParseDeal(text)
+		return err
+	}
+
+	dialog.ShowForm("Enter Deal", "Play", "Cancel", []*widget.FormItem{widget.NewFormItem("", entry)}, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		g, err := ParseDeal(entry.Text)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		t.LoadDeal(g)
+	}, w)
 }
 
 func shuffleDraw(t *Table) {
@@ -56,8 +121,10 @@ func shuffleDraw(t *Table) {
 func main() {
 	a := app.New()
 	a.SetIcon(resourceIconPng)
-	a.Settings().SetTheme(newGameTheme())
+	a.Settings().SetTheme(loadTheme(a.Preferences()))
+	loadDeckPreference(a.Preferences())
+	loadBackPreference(a.Preferences())
 
-	show(a)
+	newSession(a).openGame()
 	a.Run()
 }
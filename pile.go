@@ -0,0 +1,182 @@
+package main
+
+const (
+	// FoundationCount is the number of foundation (build) piles in a game
+	FoundationCount = 4
+	// TableauCount is the number of tableau (stack) columns in a game
+	TableauCount = 7
+)
+
+// PileType encodes which area of the table a pile belongs to
+type PileType int
+
+const (
+	// PileNone is returned when a card could not be found on the table
+	PileNone PileType = iota
+	// PileStock is the face down pile that cards are drawn from
+	PileStock
+	// PileWaste is the pile of cards that have been drawn from the stock
+	PileWaste
+	// PileFoundation is one of the build piles, ordered by suit from Ace to King
+	PileFoundation
+	// PileTableau is one of the columns of alternating colour cards
+	PileTableau
+)
+
+// PileID identifies a single pile on the table.
+// The Index is only used for foundation and tableau piles.
+type PileID struct {
+	Type  PileType
+	Index int
+}
+
+var (
+	// StockPile is the identifier of the stock (the Hand)
+	StockPile = PileID{Type: PileStock}
+	// WastePile is the identifier of the waste (the Drawn cards)
+	WastePile = PileID{Type: PileWaste}
+)
+
+// FoundationPile returns the identifier of the foundation pile at index i
+func FoundationPile(i int) PileID {
+	return PileID{Type: PileFoundation, Index: i}
+}
+
+// TableauPile returns the identifier of the tableau column at index i
+func TableauPile(i int) PileID {
+	return PileID{Type: PileTableau, Index: i}
+}
+
+// Pile returns the stack for a foundation or tableau pile identifier.
+// The stock and waste are decks rather than stacks so nil is returned for them.
+func (g *Game) Pile(id PileID) *Stack {
+	switch id.Type {
+	case PileFoundation:
+		if id.Index >= 0 && id.Index < len(g.Foundations) {
+			return g.Foundations[id.Index]
+		}
+	case PileTableau:
+		if id.Index >= 0 && id.Index < len(g.Tableau) {
+			return g.Tableau[id.Index]
+		}
+	}
+
+	return nil
+}
+
+// MoveCard attempts to move the card to the pile identified.
+// Only foundation and tableau piles can be moved to, other destinations are ignored.
+func (g *Game) MoveCard(card *Card, to PileID) {
+	switch to.Type {
+	case PileFoundation:
+		if build := g.Pile(to); build != nil {
+			g.MoveCardToBuild(build, card)
+		}
+	case PileTableau:
+		if stack := g.Pile(to); stack != nil {
+			g.MoveCardToStack(stack, card)
+		}
+	}
+}
+
+// CanMoveCard returns true if the card could be moved to the pile identified.
+// Only the top card of a pile can be moved to a foundation, and a card cannot move to the pile it is already in.
+func (g *Game) CanMoveCard(card *Card, to PileID) bool {
+	dest := g.Pile(to)
+	if card == nil || dest == nil {
+		return false
+	}
+	from := g.PileForCard(card)
+	switch {
+	case from == to || from.Type == PileNone || from.Type == PileStock:
+		return false
+	case from.Type == PileWaste && !cardEquals(card, g.WasteTop()):
+		return false
+	}
+
+	switch to.Type {
+	case PileFoundation:
+		if from.Type == PileFoundation {
+			return false // moving between foundations achieves nothing
+		}
+		if stack := g.Pile(from); stack != nil && !cardEquals(stack.Top(), card) {
+			return false
+		}
+		return g.ruleCanMoveToBuild(dest, card)
+	case PileTableau:
+		return g.ruleCanMoveToStack(dest, card)
+	}
+	return false
+}
+
+// BestMove returns the most useful pile that the card can be moved to, for moving a card with a single tap.
+// A foundation is preferred, otherwise each column is ranked by what the move achieves there:
+// uncovering a face-down card is best, then a move that only shuffles a run onto a column without face-down cards,
+// then one that buries a column's face-down cards further. Columns of equal rank are tried from the left.
+// If there is no useful legal move false is returned.
+func (g *Game) BestMove(card *Card) (PileID, bool) {
+	for i := range g.Foundations {
+		if g.CanMoveCard(card, FoundationPile(i)) {
+			return FoundationPile(i), true
+		}
+	}
+
+	reveals, whole := false, false
+	if from := g.PileForCard(card); from.Type == PileTableau {
+		stack := g.Pile(from)
+		index := stack.indexOf(card)
+		reveals = index > 0 && !stack.Cards[index-1].FaceUp
+		whole = index == 0
+	}
+
+	best, bestRank := PileID{Type: PileNone}, 0
+	for i, s := range g.Tableau {
+		id := TableauPile(i)
+		if !g.CanMoveCard(card, id) {
+			continue
+		}
+
+		if len(s.Cards) == 0 && whole {
+			continue // moving a whole column to a space achieves nothing
+		}
+
+		rank := 1
+		if !reveals {
+			rank = 2
+			if len(s.Cards) > 0 && !s.Cards[0].FaceUp {
+				rank = 3
+			}
+		}
+		if best.Type == PileNone || rank < bestRank {
+			best, bestRank = id, rank
+		}
+	}
+	return best, best.Type != PileNone
+}
+
+// PileForCard returns the identifier of the pile that currently holds the specified card.
+// If the card is not on the table the returned PileID will have type PileNone.
+func (g *Game) PileForCard(card *Card) PileID {
+	for i, s := range g.Tableau {
+		if s.Contains(card) {
+			return TableauPile(i)
+		}
+	}
+	for i, b := range g.Foundations {
+		if b.Contains(card) {
+			return FoundationPile(i)
+		}
+	}
+	for _, c := range g.Drawn.Cards {
+		if cardEquals(c, card) {
+			return WastePile
+		}
+	}
+	for _, c := range g.Hand.Cards {
+		if cardEquals(c, card) {
+			return StockPile
+		}
+	}
+
+	return PileID{Type: PileNone}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGame_Pile(t *testing.T) {
+	game := newTestGame()
+
+	assert.Equal(t, game.Tableau[2], game.Pile(TableauPile(2)))
+	assert.Equal(t, game.Foundations[3], game.Pile(FoundationPile(3)))
+	assert.Nil(t, game.Pile(TableauPile(TableauCount)))
+	assert.Nil(t, game.Pile(StockPile))
+	assert.Nil(t, game.Pile(WastePile))
+}
+
+func TestGame_PileForCard(t *testing.T) {
+	game := newTestGame()
+
+	assert.Equal(t, TableauPile(0), game.PileForCard(game.Tableau[0].Cards[0]))
+	assert.Equal(t, TableauPile(6), game.PileForCard(game.Tableau[6].Cards[3]))
+	assert.Equal(t, StockPile, game.PileForCard(game.Hand.Cards[0]))
+
+	game.DrawThree()
+	assert.Equal(t, WastePile, game.PileForCard(game.Draw1))
+
+	built := game.Tableau[6].Top()
+	game.Tableau[6].Pop()
+	game.Foundations[1].Push(built)
+	assert.Equal(t, FoundationPile(1), game.PileForCard(built))
+
+	lost := game.Hand.Pop()
+	assert.Equal(t, PileNone, game.PileForCard(lost).Type)
+}
+
+func TestGame_MoveCard(t *testing.T) {
+	game := newTestGame()
+
+	game.Tableau[0].Cards[0].Value = 3
+	game.Tableau[0].Cards[0].Suit = SuitClubs
+	game.Tableau[1].Cards[1].Value = 2
+	game.Tableau[1].Cards[1].Suit = SuitDiamonds
+
+	game.MoveCard(game.Tableau[1].Cards[1], StockPile)
+	assert.Equal(t, 2, len(game.Tableau[1].Cards))
+
+	game.MoveCard(game.Tableau[1].Cards[1], TableauPile(0))
+	assert.Equal(t, 2, len(game.Tableau[0].Cards))
+	assert.Equal(t, 1, len(game.Tableau[1].Cards))
+}
+
+func TestGame_CanMoveCard(t *testing.T) {
+	game := newTestGame()
+	ace := &Card{Value: 1, Suit: SuitSpades, FaceUp: true}
+	two := &Card{Value: 2, Suit: SuitHearts, FaceUp: true}
+	three := &Card{Value: 3, Suit: SuitClubs, FaceUp: true}
+	game.Tableau[0].Cards = []*Card{three}
+	game.Tableau[1].Cards = []*Card{two, ace}
+
+	assert.True(t, game.CanMoveCard(ace, FoundationPile(0)))
+	assert.False(t, game.CanMoveCard(two, FoundationPile(0)))
+	assert.True(t, game.CanMoveCard(two, TableauPile(0)))
+	assert.False(t, game.CanMoveCard(ace, TableauPile(0)))
+	assert.False(t, game.CanMoveCard(two, TableauPile(1)))
+	assert.False(t, game.CanMoveCard(ace, WastePile))
+	assert.False(t, game.CanMoveCard(game.Hand.Cards[0], TableauPile(2)))
+}
+
+func TestGame_BestMove_Foundation(t *testing.T) {
+	game := newEmptyGame()
+	ace := &Card{Value: 1, Suit: SuitHearts, FaceUp: true}
+	two := &Card{Value: 2, Suit: SuitClubs, FaceUp: true}
+	game.Tableau[0].Cards = []*Card{two}
+	game.Tableau[1].Cards = []*Card{ace}
+
+	to, ok := game.BestMove(ace)
+	assert.True(t, ok)
+	assert.Equal(t, FoundationPile(0), to)
+}
+
+func TestGame_BestMove_Column(t *testing.T) {
+	game := newEmptyGame()
+	hidden := &Card{Value: 9, Suit: SuitSpades}
+	four := &Card{Value: 4, Suit: SuitHearts, FaceUp: true}
+	game.Tableau[0].Cards = []*Card{hidden, four}
+	game.Tableau[2].Cards = []*Card{{Value: 8, Suit: SuitDiamonds}, {Value: 5, Suit: SuitClubs, FaceUp: true}}
+	game.Tableau[4].Cards = []*Card{{Value: 5, Suit: SuitSpades, FaceUp: true}}
+
+	// uncovering the nine is worth it whichever column is used, so the leftmost is picked
+	to, ok := game.BestMove(four)
+	assert.True(t, ok)
+	assert.Equal(t, TableauPile(2), to)
+
+	// only shuffling the run, so avoid burying the hidden eight
+	hidden.FaceUp = true
+	to, ok = game.BestMove(four)
+	assert.True(t, ok)
+	assert.Equal(t, TableauPile(4), to)
+}
+
+func TestGame_BestMove_EmptyColumn(t *testing.T) {
+	game := newEmptyGame()
+	king := &Card{Value: ValueKing, Suit: SuitHearts, FaceUp: true}
+	game.Tableau[3].Cards = []*Card{king}
+
+	_, ok := game.BestMove(king)
+	assert.False(t, ok)
+
+	game.Tableau[3].Cards = []*Card{{Value: 9, Suit: SuitSpades}, king}
+	to, ok := game.BestMove(king)
+	assert.True(t, ok)
+	assert.Equal(t, TableauPile(0), to)
+}
+
+func TestGame_BestMove_None(t *testing.T) {
+	game := newEmptyGame()
+	six := &Card{Value: 6, Suit: SuitHearts, FaceUp: true}
+	game.Tableau[0].Cards = []*Card{six}
+	game.Tableau[1].Cards = []*Card{{Value: 8, Suit: SuitClubs, FaceUp: true}}
+
+	_, ok := game.BestMove(six)
+	assert.False(t, ok)
+}
@@ -5,7 +5,6 @@ import (
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/theme"
-	"fyne.io/fyne/v2/widget"
 
 	"github.com/fyne-io/solitaire/faces"
 )
@@ -14,33 +13,23 @@ const minCardWidth = float32(95)
 const minPadding = float32(4)
 const cardRatio = 142.0 / minCardWidth
 
-var (
-	cardSize = fyne.Size{Width: minCardWidth, Height: minCardWidth * cardRatio}
-
-	smallPad = minPadding
-	overlap  = smallPad * 5
-	bigPad   = smallPad + overlap
-
-	minWidth  = cardSize.Width*7 + smallPad*6
-	minHeight = cardSize.Height*3 + smallPad*2 + 1
+const (
+	// minTableauRows is the number of card heights that a tableau column must have room for
+	minTableauRows = 2.5
+	// maxFan is the most space shown of each face up card in a column, as a fraction of the card height
+	maxFan = 0.25
+	// portraitFan replaces maxFan in portrait, where the table has height to spare below the columns
+	portraitFan = 0.35
+	// indexFan is the space needed to read the index of a face up card, as a fraction of the card height
+	indexFan = 0.18
+	// faceDownFan is the space shown of each face down card, as a fraction of the card height
+	faceDownFan = 0.06
 )
 
-func updateCardPosition(c *canvas.Image, x, y float32) {
-	c.Resize(cardSize)
-	c.Move(fyne.NewPos(x, y))
-}
-
-func withinCardBounds(c *canvas.Image, pos fyne.Position) bool {
-	if pos.X < c.Position().X || pos.Y < c.Position().Y {
-		return false
-	}
-
-	if pos.X >= c.Position().X+c.Size().Width || pos.Y >= c.Position().Y+c.Size().Height {
-		return false
-	}
-
-	return true
-}
+const (
+	minWidth  = minCardWidth*7 + minPadding*6
+	minHeight = minCardWidth*cardRatio*3 + minPadding*2 + 1
+)
 
 func newCardPos(card *Card) *canvas.Image {
 	if card == nil {
@@ -53,82 +42,62 @@ func newCardPos(card *Card) *canvas.Image {
 	} else {
 		face = faces.ForBack()
 	}
-	image := &canvas.Image{Resource: face}
-	image.Resize(cardSize)
-
-	return image
+	return &canvas.Image{Resource: face}
 }
 
 func newCardSpace() *canvas.Image {
-	space := faces.ForSpace()
-	image := &canvas.Image{Resource: space}
-	image.Resize(cardSize)
-
-	return image
+	return &canvas.Image{Resource: faces.ForSpace()}
 }
 
 type tableRender struct {
-	game *Game
+	mirrored bool // true when laid out for left handed play
 
-	deck *canvas.Image
-	sep  *widget.Separator
+	felt       *canvas.Rectangle
+	background *canvas.Image
+	deck       *canvas.Image
+	noRedeal   *canvas.Image
+	sep        *canvas.Rectangle
 
-	pile1, pile2, pile3            *canvas.Image
-	build1, build2, build3, build4 *canvas.Image
+	pile1, pile2, pile3 *canvas.Image
+	builds              []*canvas.Image
+	highlights          map[PileID]*canvas.Rectangle
 
-	stack1, stack2, stack3, stack4, stack5, stack6, stack7 *stackRender
+	stacks []*stackRender
 
 	objects []fyne.CanvasObject
 	table   *Table
 }
 
-func updateSizes(pad float32) {
-	smallPad = pad
-	overlap = smallPad * 5
-}
-
 func (t *tableRender) MinSize() fyne.Size {
 	return fyne.NewSize(220, 140)
 }
 
 func (t *tableRender) Layout(size fyne.Size) {
-	padding := size.Width * .006
-	updateSizes(padding)
-
-	newWidth := (size.Width - smallPad*6) / 7.0
-	cardSize = fyne.NewSize(newWidth, newWidth*cardRatio)
-
-	updateCardPosition(t.deck, 0, 0)
-
-	updateCardPosition(t.pile1, smallPad+cardSize.Width, 0)
-	updateCardPosition(t.pile2, smallPad+cardSize.Width+overlap, 0)
-	updateCardPosition(t.pile3, smallPad+cardSize.Width+overlap*2, 0)
-	updateCardPosition(t.table.float[0], 0, 0)
-
-	updateCardPosition(t.build1, size.Width-smallPad*3-cardSize.Width*4, 0)
-	updateCardPosition(t.build2, size.Width-smallPad*2-cardSize.Width*3, 0)
-	updateCardPosition(t.build3, size.Width-smallPad-cardSize.Width*2, 0)
-	updateCardPosition(t.build4, size.Width-cardSize.Width, 0)
-
-	sepThick := theme.SeparatorThicknessSize()
-	t.sep.Resize(fyne.NewSize(size.Width, sepThick))
-	t.sep.Move(fyne.NewPos(0, cardSize.Height+smallPad))
-
-	stackYPos := smallPad*2 + sepThick + cardSize.Height
-	t.stack1.Layout(fyne.NewPos(0, stackYPos),
-		fyne.NewSize(cardSize.Width, size.Height-stackYPos))
-	t.stack2.Layout(fyne.NewPos(smallPad+cardSize.Width, stackYPos),
-		fyne.NewSize(cardSize.Width, size.Height-stackYPos))
-	t.stack3.Layout(fyne.NewPos((smallPad+cardSize.Width)*2, stackYPos),
-		fyne.NewSize(cardSize.Width, size.Height-stackYPos))
-	t.stack4.Layout(fyne.NewPos((smallPad+cardSize.Width)*3, stackYPos),
-		fyne.NewSize(cardSize.Width, size.Height-stackYPos))
-	t.stack5.Layout(fyne.NewPos((smallPad+cardSize.Width)*4, stackYPos),
-		fyne.NewSize(cardSize.Width, size.Height-stackYPos))
-	t.stack6.Layout(fyne.NewPos((smallPad+cardSize.Width)*5, stackYPos),
-		fyne.NewSize(cardSize.Width, size.Height-stackYPos))
-	t.stack7.Layout(fyne.NewPos((smallPad+cardSize.Width)*6, stackYPos),
-		fyne.NewSize(cardSize.Width, size.Height-stackYPos))
+	layout := t.table.layout
+	layout.resize(size, t.table.leftHanded)
+	t.mirrored = layout.mirrored
+
+	t.felt.Resize(size)
+	t.background.Resize(size)
+	card := layout.cardSize
+	layout.placeCard(t.deck, layout.stockPos())
+	t.noRedeal.Resize(fyne.NewSize(card.Width/2, card.Width/2))
+	t.noRedeal.Move(layout.stockPos().AddXY(card.Width/4, (card.Height-card.Width/2)/2))
+
+	layout.placeCard(t.pile1, layout.wastePos(0))
+	layout.placeCard(t.pile2, layout.wastePos(1))
+	layout.placeCard(t.pile3, layout.wastePos(2))
+
+	for i, b := range t.builds {
+		layout.placeCard(b, layout.foundationPos(i))
+	}
+
+	t.sep.Resize(fyne.NewSize(size.Width, theme.SeparatorThicknessSize()))
+	t.sep.Move(fyne.NewPos(0, layout.sepY))
+
+	for i, s := range t.stacks {
+		s.Layout(layout.columnPos(i), fyne.NewSize(card.Width, size.Height-layout.stackY))
+	}
 }
 
 func (t *tableRender) ApplyTheme() {
@@ -145,6 +114,7 @@ func (t *tableRender) refreshCardOrBlank(img *canvas.Image, card *Card) {
 	img.Image = nil
 	if card == nil {
 		img.Resource = faces.ForSpace()
+		img.Refresh()
 		return
 	}
 
@@ -154,7 +124,7 @@ func (t *tableRender) refreshCardOrBlank(img *canvas.Image, card *Card) {
 		img.Resource = faces.ForBack()
 	}
 
-	if t.table.selected != nil && cardEquals(card, t.table.selected) {
+	if t.table.selected != nil && t.table.drag == nil && cardEquals(card, t.table.selected) {
 		img.Translucency = 0.25
 	} else {
 		img.Translucency = 0
@@ -162,35 +132,84 @@ func (t *tableRender) refreshCardOrBlank(img *canvas.Image, card *Card) {
 	img.Refresh()
 }
 
+// refreshWaste draws one of the visible waste cards, which is shown as a space if it is being dragged
+// from the bottom of the fan and hidden if it is dragged from elsewhere
+func (t *tableRender) refreshWaste(img *canvas.Image, card *Card, index int) {
+	if t.table.dragged(WastePile, index) {
+		img.Hidden = index > 0
+		t.refreshCardOrBlank(img, nil)
+		return
+	}
+	t.refreshCard(img, card)
+}
+
 func (t *tableRender) Refresh() {
-	if len(t.game.Hand.Cards) > 0 {
+	game := t.table.game
+	if t.mirrored != t.table.leftHanded {
+		t.Layout(t.table.Size())
+	}
+	t.felt.FillColor = theme.Color(colorNameFelt)
+	canvas.Refresh(t.felt)
+	t.sep.FillColor = theme.Color(colorNameFeltLine)
+	if t.background.Resource != t.table.background {
+		t.background.Resource = t.table.background
+		t.background.Hidden = t.table.background == nil
+		canvas.Refresh(t.background)
+	}
+
+	if len(game.Hand.Cards) > 0 {
 		t.deck.Resource = faces.ForBack()
 	} else {
 		t.deck.Resource = faces.ForSpace()
 	}
 	canvas.Refresh(t.deck)
+	t.noRedeal.Hidden = !game.StockExhausted()
+	canvas.Refresh(t.noRedeal)
 	canvas.Refresh(t.sep)
 
-	t.refreshCard(t.pile1, t.game.Draw1)
-	t.refreshCard(t.pile2, t.game.Draw2)
-	t.refreshCard(t.pile3, t.game.Draw3)
+	t.refreshWaste(t.pile1, game.Draw1, 0)
+	t.refreshWaste(t.pile2, game.Draw2, 1)
+	t.refreshWaste(t.pile3, game.Draw3, 2)
 
-	t.refreshCardOrBlank(t.build1, t.game.Build1.Top())
-	t.refreshCardOrBlank(t.build2, t.game.Build2.Top())
-	t.refreshCardOrBlank(t.build3, t.game.Build3.Top())
-	t.refreshCardOrBlank(t.build4, t.game.Build4.Top())
+	for i, b := range t.builds {
+		build := game.Foundations[i]
+		top := len(build.Cards) - 1
+		if t.table.dragged(FoundationPile(i), top) {
+			top-- // show the card beneath one lifted from a foundation, so the pile does not look empty
+		}
 
-	t.stack1.Refresh(t.game.Stack1)
-	t.stack2.Refresh(t.game.Stack2)
-	t.stack3.Refresh(t.game.Stack3)
-	t.stack4.Refresh(t.game.Stack4)
-	t.stack5.Refresh(t.game.Stack5)
-	t.stack6.Refresh(t.game.Stack6)
-	t.stack7.Refresh(t.game.Stack7)
+		var card *Card
+		if top >= 0 {
+			card = build.Cards[top]
+		}
+		t.refreshCardOrBlank(b, card)
+	}
 
+	for i, s := range t.stacks {
+		s.Refresh(game.Tableau[i], t.table.draggedFrom(TableauPile(i)))
+	}
+
+	t.refreshHighlights()
 	canvas.Refresh(t.table)
 }
 
+// refreshHighlights outlines the piles that the cards being dragged could be dropped on
+func (t *tableRender) refreshHighlights() {
+	for _, h := range t.highlights {
+		h.Hide()
+	}
+	for _, id := range t.table.targets {
+		h := t.highlights[id]
+		h.StrokeColor = theme.Color(theme.ColorNamePrimary)
+		h.Move(t.table.layout.pilePos(t.table.game, id))
+		h.Resize(t.table.layout.cardSize)
+		h.Show()
+	}
+	for _, h := range t.highlights {
+		canvas.Refresh(h)
+	}
+}
+
 func (t *tableRender) Objects() []fyne.CanvasObject {
 	return t.objects
 }
@@ -204,107 +223,41 @@ func (t *tableRender) appendStack(stack *stackRender) {
 	}
 }
 
-func (t *tableRender) positionForCard(_ *Card) *canvas.Image {
-	return nil
-}
-
-func (t *tableRender) findCard(pos fyne.Position) ([]*Card, []*canvas.Image, bool) {
-	if t.game.Draw3 != nil {
-		if withinCardBounds(t.pile3, pos) {
-			return []*Card{t.game.Draw3}, []*canvas.Image{t.pile3}, false
-		}
-	} else if t.game.Draw2 != nil {
-		if withinCardBounds(t.pile2, pos) {
-			return []*Card{t.game.Draw2}, []*canvas.Image{t.pile2}, false
-		}
-	} else if t.game.Draw1 != nil {
-		if withinCardBounds(t.pile1, pos) {
-			return []*Card{t.game.Draw1}, []*canvas.Image{t.pile1}, true
-		}
-	}
-
-	// Skipping build piles as we can't drag out...
-
-	if c, p := t.findOnStack(t.stack1, t.game.Stack1, pos); c != nil {
-		return c, p, len(t.game.Stack1.Cards) == 1
-	} else if c, p := t.findOnStack(t.stack2, t.game.Stack2, pos); c != nil {
-		return c, p, len(t.game.Stack2.Cards) == 1
-	} else if c, p := t.findOnStack(t.stack3, t.game.Stack3, pos); c != nil {
-		return c, p, len(t.game.Stack3.Cards) == 1
-	} else if c, p := t.findOnStack(t.stack4, t.game.Stack4, pos); c != nil {
-		return c, p, len(t.game.Stack4.Cards) == 1
-	} else if c, p := t.findOnStack(t.stack5, t.game.Stack5, pos); c != nil {
-		return c, p, len(t.game.Stack5.Cards) == 1
-	} else if c, p := t.findOnStack(t.stack6, t.game.Stack6, pos); c != nil {
-		return c, p, len(t.game.Stack6.Cards) == 1
-	} else if c, p := t.findOnStack(t.stack7, t.game.Stack7, pos); c != nil {
-		return c, p, len(t.game.Stack7.Cards) == 1
-	}
-
-	return nil, nil, false
-}
-
-func (t *tableRender) findOnStack(render *stackRender, stack *Stack, pos fyne.Position) ([]*Card, []*canvas.Image) {
-	for i := len(stack.Cards) - 1; i >= 0; i-- {
-		if withinCardBounds(render.cards[i], pos) {
-			return stack.Cards[i:len(stack.Cards)], render.cards[i:len(stack.Cards)]
-		}
-	}
-
-	return nil, nil
-}
-
-func (t *tableRender) stackPos(i int) fyne.Position {
-	top := t.table.Position().Y
-	size := t.table.Size()
-	switch i {
-	case 3:
-		return fyne.NewPos(size.Width-cardSize.Width, top)
-	case 2:
-		return fyne.NewPos(size.Width-smallPad-cardSize.Width*2, top)
-	case 1:
-		return fyne.NewPos(size.Width-smallPad*2-cardSize.Width*3, top)
-	default:
-		return fyne.NewPos(size.Width-smallPad*3-cardSize.Width*4, top)
-	}
-}
-
 func newTableRender(table *Table) *tableRender {
-	render := &tableRender{}
-	table.findCard = render.findCard
-	table.stackPos = render.stackPos
-	render.table = table
-	render.game = table.game
+	render := &tableRender{table: table}
+	render.felt = canvas.NewRectangle(theme.Color(colorNameFelt))
+	render.background = &canvas.Image{FillMode: canvas.ImageFillStretch}
+	render.background.Hide()
 	render.deck = newCardPos(nil)
-	render.sep = widget.NewSeparator()
+	render.noRedeal = canvas.NewImageFromResource(theme.CancelIcon())
+	render.noRedeal.Hide()
+	render.sep = canvas.NewRectangle(theme.Color(colorNameFeltLine))
 
 	render.pile1 = newCardPos(nil)
 	render.pile2 = newCardPos(nil)
 	render.pile3 = newCardPos(nil)
 
-	render.build1 = newCardSpace()
-	render.build2 = newCardSpace()
-	render.build3 = newCardSpace()
-	render.build4 = newCardSpace()
-
-	render.stack1 = newStackRender(render)
-	render.stack2 = newStackRender(render)
-	render.stack3 = newStackRender(render)
-	render.stack4 = newStackRender(render)
-	render.stack5 = newStackRender(render)
-	render.stack6 = newStackRender(render)
-	render.stack7 = newStackRender(render)
-
-	render.objects = []fyne.CanvasObject{render.deck, render.sep, render.pile1, render.pile2, render.pile3,
-		render.build1, render.build2, render.build3, render.build4}
-
-	render.appendStack(render.stack1)
-	render.appendStack(render.stack2)
-	render.appendStack(render.stack3)
-	render.appendStack(render.stack4)
-	render.appendStack(render.stack5)
-	render.appendStack(render.stack6)
-	render.appendStack(render.stack7)
+	render.objects = []fyne.CanvasObject{render.felt, render.background, render.deck, render.noRedeal, render.sep, render.pile1, render.pile2, render.pile3}
+
+	render.builds = make([]*canvas.Image, FoundationCount)
+	for i := range render.builds {
+		render.builds[i] = newCardSpace()
+		render.objects = append(render.objects, render.builds[i])
+	}
+
+	render.stacks = make([]*stackRender, TableauCount)
+	for i := range render.stacks {
+		render.stacks[i] = newStackRender(render)
+		render.appendStack(render.stacks[i])
+	}
+
+	render.highlights = make(map[PileID]*canvas.Rectangle)
+	for _, id := range dropPiles() {
+		h := &canvas.Rectangle{StrokeWidth: 3, CornerRadius: 6}
+		h.Hide()
+		render.highlights[id] = h
+		render.objects = append(render.objects, h)
+	}
 
 	floats := container.NewWithoutLayout()
 	for i := 0; i < len(table.float); i++ {
@@ -316,34 +269,98 @@ func newTableRender(table *Table) *tableRender {
 }
 
 type stackRender struct {
-	cards [19]*canvas.Image
+	cards [maxStackCards]*canvas.Image
 	table *tableRender
+
+	stack *Stack
+	pos   fyne.Position
+	size  fyne.Size
 }
 
-func (s *stackRender) Layout(pos fyne.Position, _ fyne.Size) {
-	top := pos.Y
-	for i := range s.cards {
-		updateCardPosition(s.cards[i], pos.X, top)
+// Layout positions the cards in the column within the space available.
+// The fan is spread to use the height of the table and shrinks so that a long column does not overflow.
+func (s *stackRender) Layout(pos fyne.Position, size fyne.Size) {
+	s.pos, s.size = pos, size
 
-		top += overlap
+	layout := s.table.table.layout
+	offsets := layout.columnOffsets(s.stack)
+	for i, c := range s.cards {
+		layout.placeCard(c, pos.AddXY(0, offsets[i]))
 	}
 }
 
-func (s *stackRender) Refresh(stack *Stack) {
-	var i int
-	var card *Card
-	if len(stack.Cards) == 0 {
-		s.cards[0].Resource = faces.ForSpace()
-		s.cards[0].Translucency = 0
-		s.cards[0].Refresh()
-		i = 0
+// fanOffsets returns the distance from the top of a column to each of the slots for cards in the column.
+// Face down cards are stacked tightly and face up cards are fanned by up to fan of the card height.
+// If the cards would not fit within height the face up cards are squeezed first, down to the size of
+// the index, and then all of the cards are squeezed as needed.
+func fanOffsets(cards []*Card, slots int, cardHeight, fan, height float32) []float32 {
+	down, up := 0, 0
+	for _, c := range cards {
+		if c.FaceUp {
+			up++
+		} else {
+			down++
+		}
+	}
+
+	// the last card needs no gap below it
+	downGaps, upGaps := float32(down), float32(up-1)
+	if up == 0 {
+		downGaps, upGaps = float32(down-1), 0
+	}
+	downGap, upGap := cardHeight*faceDownFan, cardHeight*fan
+	space := height - cardHeight
+	if downGaps > 0 || upGaps > 0 {
+		if upGaps > 0 && downGaps*downGap+upGaps*upGap > space {
+			upGap = (space - downGaps*downGap) / upGaps
+			if least := cardHeight * indexFan; upGap < least {
+				upGap = least
+			}
+		}
+		if need := downGaps*downGap + upGaps*upGap; need > space {
+			scale := space / need
+			if scale < 0 {
+				scale = 0
+			}
+			downGap, upGap = downGap*scale, upGap*scale
+		}
+	}
+
+	offsets := make([]float32, slots)
+	top := float32(0)
+	for i := range offsets {
+		offsets[i] = top
+		if i < len(cards) && !cards[i].FaceUp {
+			top += downGap
+		} else {
+			top += upGap
+		}
+	}
+	return offsets
+}
+
+// Refresh draws the cards of the column, hiding those from index dragged onwards as they are being dragged
+func (s *stackRender) Refresh(stack *Stack, dragged int) {
+	s.stack = stack
+	s.Layout(s.pos, s.size)
+
+	count := len(stack.Cards)
+	if dragged >= 0 && dragged < count {
+		count = dragged
+	}
+
+	i := 0
+	if count == 0 {
+		s.table.refreshCardOrBlank(s.cards[0], nil)
+		s.cards[0].Show()
+		i = 1
 	} else {
-		for i, card = range stack.Cards {
-			s.table.refreshCard(s.cards[i], card)
+		for ; i < count; i++ {
+			s.table.refreshCard(s.cards[i], stack.Cards[i])
 		}
 	}
 
-	for i = i + 1; i < len(s.cards); i++ {
+	for ; i < len(s.cards); i++ {
 		s.cards[i].Image = nil
 		s.cards[i].Resource = nil
 		s.cards[i].Hide()
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableRender_LayoutPortrait(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+
+	table := NewTable(NewGameFromSeed(1))
+	render := test.WidgetRenderer(table).(*tableRender)
+	render.Layout(fyne.NewSize(400, 800))
+
+	assert.Equal(t, float32(0), table.layout.left)
+	assert.InDelta(t, (400-table.layout.pad*6)/7, table.layout.cardSize.Width, 0.01)
+	last := render.stacks[6].cards[6]
+	assert.Less(t, last.Position().Y+last.Size().Height, float32(800))
+
+	run := []*Card{{FaceUp: true}, {FaceUp: true}, {FaceUp: true}}
+	cardHeight := table.layout.cardSize.Height
+	assert.InDelta(t, cardHeight*portraitFan, table.layout.columnOffsets(&Stack{Cards: run})[1], 0.01)
+
+	render.Layout(fyne.NewSize(800, 700))
+	assert.InDelta(t, table.layout.cardSize.Height*maxFan, table.layout.columnOffsets(&Stack{Cards: run})[1], 0.01)
+}
+
+func TestTableRender_LayoutLandscape(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+
+	table := NewTable(NewGameFromSeed(1))
+	render := test.WidgetRenderer(table).(*tableRender)
+	render.Layout(fyne.NewSize(1200, 400))
+
+	left := table.layout.left
+	assert.Greater(t, left, float32(0))
+	assert.Less(t, table.layout.cardSize.Height*minTableauRows, float32(400))
+	assert.Equal(t, left, render.deck.Position().X)
+	assert.InDelta(t, 1200-left, render.builds[3].Position().X+table.layout.cardSize.Width, 0.01)
+}
+
+func TestTableRender_LayoutPerTable(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+
+	small, large := NewTable(NewGameFromSeed(1)), NewTable(NewGameFromSeed(2))
+	test.WidgetRenderer(small).Layout(fyne.NewSize(400, 300))
+	test.WidgetRenderer(large).Layout(fyne.NewSize(1400, 1000))
+
+	assert.Less(t, small.layout.cardSize.Width, large.layout.cardSize.Width)
+	assert.Equal(t, small.layout.cardSize, test.WidgetRenderer(small).(*tableRender).deck.Size())
+	pos := small.layout.columnPos(0).AddXY(1, 1)
+	h, ok := small.layout.hit(small.game, pos)
+	assert.True(t, ok)
+	assert.Equal(t, TableauPile(0), h.pile)
+}
+
+func TestStackRender_LayoutFitsHeight(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+
+	table := NewTable(NewGameFromSeed(1))
+	render := test.WidgetRenderer(table).(*tableRender)
+	render.Layout(fyne.NewSize(700, 500))
+
+	stack := &Stack{}
+	for i := 0; i < 6; i++ {
+		stack.Push(NewCard(i+1, SuitClubs))
+	}
+	for i := ValueKing; i > 0; i-- {
+		c := NewCard(i, SuitHearts)
+		c.FaceUp = true
+		stack.Push(c)
+	}
+	s := render.stacks[0]
+	s.Refresh(stack, -1)
+
+	last := s.cards[len(stack.Cards)-1]
+	assert.LessOrEqual(t, last.Position().Y+last.Size().Height, s.pos.Y+s.size.Height+0.01)
+	downGap := s.cards[1].Position().Y - s.cards[0].Position().Y
+	upGap := s.cards[8].Position().Y - s.cards[7].Position().Y
+	assert.Less(t, downGap, upGap)
+}
+
+func TestTableRender_LayoutLeftHanded(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+
+	table := NewTable(NewGameFromSeed(1))
+	table.Resize(fyne.NewSize(700, 500))
+	render := test.WidgetRenderer(table).(*tableRender)
+	render.Layout(table.Size())
+	right := render.deck.Position().X
+
+	table.SetLeftHanded(true)
+	assert.Greater(t, render.deck.Position().X, right)
+	assert.Equal(t, table.layout.columnX(0), render.builds[3].Position().X)
+	assert.Less(t, render.pile3.Position().X, render.pile1.Position().X)
+	assert.Equal(t, render.builds[0].Position(), table.layout.foundationPos(0))
+
+	table.game.DrawThree()
+	table.Refresh()
+	h, ok := table.layout.hit(table.game, fyne.NewPos(render.pile3.Position().X+1, 1))
+	assert.True(t, ok)
+	assert.Equal(t, []*Card{table.game.Draw3}, h.cards(table.game))
+}
+
+func TestFanOffsets(t *testing.T) {
+	var cards []*Card
+	for i := 0; i < 3; i++ {
+		cards = append(cards, NewCard(i+1, SuitClubs))
+	}
+	for i := 0; i < 2; i++ {
+		c := NewCard(i+5, SuitSpades)
+		c.FaceUp = true
+		cards = append(cards, c)
+	}
+
+	offsets := fanOffsets(cards, 6, 100, maxFan, 1000)
+	assert.Equal(t, []float32{0, 6, 12, 18, 43, 68}, offsets)
+}
+
+func TestFanOffsets_Squeeze(t *testing.T) {
+	var cards []*Card
+	for i := 0; i < 6; i++ {
+		cards = append(cards, NewCard(i+1, SuitClubs))
+	}
+	for i := ValueKing; i > 0; i-- {
+		c := NewCard(i, SuitHearts)
+		c.FaceUp = true
+		cards = append(cards, c)
+	}
+
+	// face up cards shrink first
+	offsets := fanOffsets(cards, len(cards), 100, maxFan, 360)
+	assert.InDelta(t, 6, offsets[1]-offsets[0], 0.01)
+	assert.InDelta(t, 224.0/12, offsets[8]-offsets[7], 0.01)
+	assert.InDelta(t, 360, offsets[len(cards)-1]+100, 0.01)
+
+	// then everything shrinks to fit
+	offsets = fanOffsets(cards, len(cards), 100, maxFan, 250)
+	assert.InDelta(t, 250, offsets[len(cards)-1]+100, 0.01)
+	assert.Less(t, offsets[1]-offsets[0], float32(6))
+	assert.Less(t, offsets[8]-offsets[7], float32(18))
+}
@@ -1,5 +1,14 @@
 package main
 
+const (
+	// PassesUnlimited allows the stock to be recycled as many times as the player likes
+	PassesUnlimited = 0
+	// PassesThree allows the stock to be drawn through three times
+	PassesThree = 3
+	// PassesOne allows only a single pass through the stock, as in Vegas style play
+	PassesOne = 1
+)
+
 func (g *Game) ruleCanMoveToBuild(build *Stack, card *Card) bool {
 	if len(build.Cards) == 0 {
 		return card.Value == 1
@@ -20,3 +29,43 @@ func (g *Game) ruleCanMoveToStack(stack *Stack, card *Card) bool {
 	}
 	return card.Value == top.Value-1
 }
+
+// ruleSafeToBuild returns true if moving the card to a foundation can never make the game harder to win.
+// Aces and twos are always safe, higher cards once both cards of the opposite colour one rank lower are built.
+func (g *Game) ruleSafeToBuild(card *Card) bool {
+	if card.Value <= 2 {
+		return true
+	}
+
+	for suit := SuitClubs; suit <= SuitSpades; suit++ {
+		if (&Card{Suit: suit}).Color() == card.Color() {
+			continue
+		}
+		if g.foundationValue(suit) < card.Value-1 {
+			return false
+		}
+	}
+	return true
+}
+
+// foundationValue returns the highest card of a suit on the foundations, or 0 if the ace is not yet built
+func (g *Game) foundationValue(suit Suit) int {
+	for _, b := range g.Foundations {
+		if top := b.Top(); top != nil && top.Suit == suit {
+			return top.Value
+		}
+	}
+	return 0
+}
+
+func (g *Game) ruleCanRecycle() bool {
+	if g.MaxPasses == PassesUnlimited {
+		return true
+	}
+
+	return g.Redeals < g.MaxPasses-1
+}
+
+func (g *Game) ruleCanShuffleStock() bool {
+	return !g.FixedStock && len(g.Drawn.Cards) == 0 && len(g.Hand.Cards) > 1
+}
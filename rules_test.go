@@ -10,44 +10,86 @@ func TestRuleCanMoveToBuild_Empty(t *testing.T) {
 	g := NewGame()
 	card := NewCard(1, SuitClubs)
 
-	assert.True(t, g.ruleCanMoveToBuild(g.Build1, card))
+	assert.True(t, g.ruleCanMoveToBuild(g.Foundations[0], card))
 	card.Value = 3
-	assert.False(t, g.ruleCanMoveToBuild(g.Build1, card))
+	assert.False(t, g.ruleCanMoveToBuild(g.Foundations[0], card))
 }
 
 func TestRuleCanMoveToBuild_Over(t *testing.T) {
 	g := NewGame()
 	card := NewCard(1, SuitClubs)
-	g.Build1.Push(card)
+	g.Foundations[0].Push(card)
 
 	card = NewCard(2, SuitClubs)
-	assert.True(t, g.ruleCanMoveToBuild(g.Build1, card))
+	assert.True(t, g.ruleCanMoveToBuild(g.Foundations[0], card))
 	card.Suit = SuitDiamonds
-	assert.False(t, g.ruleCanMoveToBuild(g.Build1, card))
+	assert.False(t, g.ruleCanMoveToBuild(g.Foundations[0], card))
 }
 
 func TestRuleCanMoveToStack_Empty(t *testing.T) {
 	g := NewGame()
 	card := NewCard(ValueKing, SuitClubs)
-	g.Stack1.Cards = []*Card{}
+	g.Tableau[0].Cards = []*Card{}
 
-	assert.True(t, g.ruleCanMoveToStack(g.Stack1, card))
+	assert.True(t, g.ruleCanMoveToStack(g.Tableau[0], card))
 	card.Value = 3
-	assert.False(t, g.ruleCanMoveToStack(g.Build1, card))
+	assert.False(t, g.ruleCanMoveToStack(g.Foundations[0], card))
 }
 
 func TestRuleCanMoveToStack_Over(t *testing.T) {
 	g := NewGame()
 	card := NewCard(10, SuitClubs)
-	g.Stack1.Cards = []*Card{card}
+	g.Tableau[0].Cards = []*Card{card}
 
 	card = NewCard(9, SuitHearts)
-	assert.True(t, g.ruleCanMoveToStack(g.Stack1, card))
+	assert.True(t, g.ruleCanMoveToStack(g.Tableau[0], card))
 	card.Value = 3
-	assert.False(t, g.ruleCanMoveToStack(g.Stack1, card))
+	assert.False(t, g.ruleCanMoveToStack(g.Tableau[0], card))
 	card.Value = 9
 	card.Suit = SuitSpades
-	assert.False(t, g.ruleCanMoveToStack(g.Stack1, card))
+	assert.False(t, g.ruleCanMoveToStack(g.Tableau[0], card))
 	card.Suit = SuitDiamonds
-	assert.True(t, g.ruleCanMoveToStack(g.Stack1, card))
+	assert.True(t, g.ruleCanMoveToStack(g.Tableau[0], card))
+}
+
+func TestRuleCanRecycle(t *testing.T) {
+	g := NewGame()
+	assert.True(t, g.ruleCanRecycle())
+	g.Redeals = 100
+	assert.True(t, g.ruleCanRecycle())
+
+	g.MaxPasses = PassesThree
+	g.Redeals = 1
+	assert.True(t, g.ruleCanRecycle())
+	g.Redeals = 2
+	assert.False(t, g.ruleCanRecycle())
+
+	g.MaxPasses = PassesOne
+	g.Redeals = 0
+	assert.False(t, g.ruleCanRecycle())
+}
+
+func TestRuleCanShuffleStock(t *testing.T) {
+	g := NewGame()
+	assert.True(t, g.ruleCanShuffleStock())
+
+	g.DrawThree()
+	assert.False(t, g.ruleCanShuffleStock())
+
+	g = NewGame()
+	g.FixedStock = true
+	assert.False(t, g.ruleCanShuffleStock())
+}
+
+func TestGame_RuleSafeToBuild(t *testing.T) {
+	game := newEmptyGame()
+	assert.True(t, game.ruleSafeToBuild(&Card{Value: 1, Suit: SuitHearts}))
+	assert.True(t, game.ruleSafeToBuild(&Card{Value: 2, Suit: SuitHearts}))
+	assert.False(t, game.ruleSafeToBuild(&Card{Value: 3, Suit: SuitHearts}))
+
+	game.Foundations[0].Cards = []*Card{{Value: 1, Suit: SuitClubs}, {Value: 2, Suit: SuitClubs}}
+	assert.False(t, game.ruleSafeToBuild(&Card{Value: 3, Suit: SuitHearts}))
+	game.Foundations[1].Cards = []*Card{{Value: 1, Suit: SuitSpades}, {Value: 2, Suit: SuitSpades}}
+	assert.True(t, game.ruleSafeToBuild(&Card{Value: 3, Suit: SuitHearts}))
+	assert.False(t, game.ruleSafeToBuild(&Card{Value: 3, Suit: SuitSpades}))
 }
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// session holds the games that are open in windows, or tabs on devices that have a single window.
+// The preferences and the app lifecycle apply to all of the games, while each has its own deal and timer.
+type session struct {
+	app   fyne.App
+	views []*gameView
+
+	main  fyne.Window
+	tabs  *container.DocTabs // the games in the main window, once more than one is open on a mobile device
+	count int                // the number of games opened, used to name them
+
+	backgroundURI string
+	background    fyne.Resource
+}
+
+// gameView is a game shown in a window or tab, along with its toolbar
+type gameView struct {
+	table   *Table
+	clock   *toolbarLabel
+	content fyne.CanvasObject
+	tab     *container.TabItem
+}
+
+func newSession(a fyne.App) *session {
+	s := &session{app: a}
+	s.loadBackground()
+
+	a.Lifecycle().SetOnExitedForeground(func() {
+		for _, v := range s.views {
+			v.table.game.Timer.Pause()
+		}
+	})
+	a.Lifecycle().SetOnEnteredForeground(func() {
+		for _, v := range s.views {
+			v.table.game.Timer.Resume()
+		}
+	})
+	a.Preferences().AddChangeListener(func() {
+		fyne.Do(s.applyPreferences)
+	})
+
+	go func() {
+		for range time.Tick(time.Second / 2) {
+			fyne.Do(s.updateClocks)
+		}
+	}()
+	return s
+}
+
+// openGame deals a new game in a new window.
+// On mobile devices, which have a single window, the games are shown as tabs instead.
+func (s *session) openGame() *gameView {
+	s.count++
+	title := "Solitaire"
+	if s.count > 1 {
+		title = fmt.Sprintf("Solitaire (Game %d)", s.count)
+	}
+
+	w := s.main
+	if w == nil || !fyne.CurrentDevice().IsMobile() {
+		w = s.app.NewWindow(title)
+	}
+	v := s.newGameView(w)
+	s.views = append(s.views, v)
+
+	if w == s.main {
+		s.addTab(v, title)
+	} else {
+		if s.main == nil {
+			s.main = w
+		}
+		w.SetContent(v.content)
+		w.Resize(fyne.NewSize(minWidth, minHeight))
+		w.SetOnClosed(func() {
+			s.closeGame(v)
+		})
+	}
+	w.Show()
+
+	if s.app.Preferences().Bool(prefWinnable) {
+		v.table.Restart()
+	}
+	return v
+}
+
+// addTab shows a game in a tab of the main window, moving the first game into a tab if needed
+func (s *session) addTab(v *gameView, title string) {
+	if s.tabs == nil {
+		first := s.views[0]
+		first.tab = container.NewTabItem("Solitaire", first.content)
+		s.tabs = container.NewDocTabs(first.tab)
+		s.tabs.OnClosed = func(item *container.TabItem) {
+			for _, open := range s.views {
+				if open.tab == item {
+					s.closeGame(open)
+					return
+				}
+			}
+		}
+		s.main.SetContent(s.tabs)
+	}
+
+	v.tab = container.NewTabItem(title, v.content)
+	s.tabs.Append(v.tab)
+	s.tabs.Select(v.tab)
+}
+
+// closeGame records a game as abandoned, unless it was already finished, and forgets about it
+func (s *session) closeGame(v *gameView) {
+	recordGame(s.app.Preferences(), v.table.game, false)
+	v.table.animations.cancelAll()
+
+	for i, open := range s.views {
+		if open == v {
+			s.views = append(s.views[:i], s.views[i+1:]...)
+			break
+		}
+	}
+}
+
+// applyPreferences updates every open game after the preferences change, possibly from another window
+func (s *session) applyPreferences() {
+	s.loadBackground()
+	for _, v := range s.views {
+		v.table.loadPreferences(s.app.Preferences())
+		v.table.SetBackground(s.background)
+	}
+}
+
+// loadBackground loads the table background image if the preference has changed
+func (s *session) loadBackground() {
+	uri := s.app.Preferences().String(prefBackground)
+	if uri == s.backgroundURI {
+		return
+	}
+
+	s.backgroundURI = uri
+	s.background = nil
+	if uri == "" {
+		return
+	}
+
+	res, err := loadBackgroundURI(uri)
+	if err != nil {
+		fyne.LogError("Unable to load table background "+uri, err)
+		return
+	}
+	s.background = res
+}
+
+func (s *session) updateClocks() {
+	for _, v := range s.views {
+		v.clock.SetText(formatDuration(v.table.game.Timer.Elapsed()))
+	}
+}
+
+// newGameView creates a table for a new game and the toolbar that controls it, using w to show dialogs
+func (s *session) newGameView(w fyne.Window) *gameView {
+	prefs := s.app.Preferences()
+	game := NewGame()
+	game.MaxPasses = prefs.IntWithFallback(prefPasses, PassesUnlimited)
+	table := NewTable(game)
+	table.loadPreferences(prefs)
+	table.sound = newSoundPlayer(newAudioBackend(), prefs)
+	table.background = s.background
+	v := &gameView{table: table}
+
+	shuffle := widget.NewToolbarAction(theme.ViewRefreshIcon(), func() {
+		table.game.ShuffleStock()
+		table.Refresh()
+	})
+	table.shuffle = shuffle
+	v.clock = &toolbarLabel{widget.NewLabel(formatDuration(0))}
+	bar := widget.NewToolbar(
+		widget.NewToolbarAction(theme.ContentAddIcon(), func() {
+			checkRestart(table, w)
+		}),
+		shuffle,
+		widget.NewToolbarAction(theme.CalendarIcon(), func() {
+			showDailyCalendar(prefs, w, table.StartDaily)
+		}),
+		widget.NewToolbarAction(theme.ContentCopyIcon(), func() {
+			s.openGame()
+		}),
+		widget.NewToolbarSpacer(),
+		v.clock,
+		widget.NewToolbarAction(theme.SettingsIcon(), func() {
+			showSettings(table, w)
+		}))
+	v.content = container.NewBorder(bar, nil, nil, nil, table)
+
+	game.OnWin = func() {
+		stats := recordGame(prefs, table.game, true)
+		elapsed := table.game.Timer.Elapsed()
+		if table.daily != "" {
+			saveDailyRecord(prefs, table.daily, &dailyRecord{Time: elapsed, Moves: table.game.Moves})
+		}
+
+		msg := fmt.Sprintf("Congratulations, you won in %s", formatDuration(elapsed))
+		if elapsed == stats.BestTime {
+			msg += "\nThat is your best time!"
+		}
+		table.celebrate(func() {
+			showWin(table, msg, w)
+		})
+	}
+	return v
+}
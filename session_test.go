@@ -0,0 +1,90 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSession_OpenGames(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	s := newSession(a)
+	windows := len(a.Driver().AllWindows())
+
+	first := s.openGame()
+	second := s.openGame()
+	assert.Equal(t, 2, len(s.views))
+	assert.Equal(t, windows+2, len(a.Driver().AllWindows()))
+	assert.NotSame(t, first.table.game, second.table.game)
+	assert.Nil(t, s.tabs)
+
+	first.table.game.DrawThree()
+	assert.True(t, first.table.game.Timer.Started())
+	assert.False(t, second.table.game.Timer.Started())
+}
+
+func TestSession_PreferencesApplyToAll(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	s := newSession(a)
+	first, second := s.openGame(), s.openGame()
+
+	a.Preferences().SetBool(prefLeftHanded, true)
+	a.Preferences().SetBool(prefAutoPlay, true)
+	assert.True(t, first.table.leftHanded)
+	assert.True(t, second.table.leftHanded)
+	assert.True(t, first.table.game.AutoPlay)
+	assert.True(t, second.table.game.AutoPlay)
+}
+
+func TestSession_StatisticsAggregate(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	s := newSession(a)
+	first, second := s.openGame(), s.openGame()
+
+	first.table.game.Timer = &Timer{elapsed: time.Minute, started: true, stopped: true}
+	second.table.game.Timer = &Timer{elapsed: time.Second, started: true, stopped: true}
+	recordGame(a.Preferences(), first.table.game, true)
+
+	s.closeGame(first)
+	s.closeGame(second)
+	assert.Empty(t, s.views)
+
+	stats := loadStatistics(a.Preferences())
+	assert.Equal(t, 2, stats.Played)
+	assert.Equal(t, 1, stats.Won)
+	assert.Equal(t, time.Minute+time.Second, stats.TotalTime)
+}
+
+func TestRecordGame_Once(t *testing.T) {
+	prefs := test.NewApp().Preferences()
+	game := newTestGame()
+	recordGame(prefs, game, false)
+	assert.Equal(t, 0, loadStatistics(prefs).Played)
+
+	game.Timer = &Timer{elapsed: time.Minute, started: true, stopped: true}
+	recordGame(prefs, game, true)
+	recordGame(prefs, game, false)
+	assert.Equal(t, 1, loadStatistics(prefs).Played)
+	assert.Equal(t, 1, loadStatistics(prefs).Won)
+}
+
+func TestTable_SetGameRecordsAbandoned(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	table := NewTable(NewGameFromSeed(1))
+
+	table.setGame(NewGameFromSeed(2), "")
+	assert.Equal(t, 0, loadStatistics(a.Preferences()).Played)
+
+	old := table.game
+	old.Timer = &Timer{elapsed: time.Minute, started: true}
+	table.setGame(NewGameFromSeed(3), "")
+	assert.True(t, old.recorded)
+	assert.Equal(t, 1, loadStatistics(a.Preferences()).Played)
+	assert.Equal(t, 0, loadStatistics(a.Preferences()).Won)
+}
@@ -0,0 +1,469 @@
+package main
+
+import (
+	"errors"
+	"image/color"
+	"io"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/solitaire/faces"
+)
+
+const (
+	prefDeckPack  = "deck.pack"
+	prefDeckPacks = "deck.packs"
+	prefCardBack  = "deck.back"
+	// prefCustomBack stores the last designed back so it can be selected again
+	prefCustomBack = "deck.back.custom"
+
+	defaultBackName  = "Deck Default"
+	customBackName   = "Custom"
+	backDesignPrefix = "design:"
+)
+
+var errInvalidEmblem = errors.New("only SVG path data is allowed")
+
+var defaultBackDesign = faces.BackDesign{Color: color.NRGBA{R: 0x1e, G: 0x4f, B: 0xa0, A: 0xff},
+	Pattern: faces.PatternDiamonds}
+
+// loadDeckPreference applies the deck pack that was chosen in a previous run.
+// If the pack can no longer be loaded the built in pack is used.
+func loadDeckPreference(p fyne.Preferences) {
+	uri := p.String(prefDeckPack)
+	if uri == "" {
+		return
+	}
+
+	pack, err := loadPackURI(uri)
+	if err != nil {
+		fyne.LogError("Unable to load deck pack "+uri, err)
+		return
+	}
+	faces.SetPack(pack)
+}
+
+// loadBackPreference applies the card back that was chosen in a previous run
+func loadBackPreference(p fyne.Preferences) {
+	faces.SetBack(backForPreference(p.String(prefCardBack)))
+}
+
+// backForPreference returns the card back for a stored preference value.
+// This is nil for the deck default, which tells the faces package to use the back from the pack.
+func backForPreference(value string) fyne.Resource {
+	if !strings.HasPrefix(value, backDesignPrefix) {
+		return faces.BuiltinBack(value)
+	}
+
+	d, err := faces.ParseBackDesign(strings.TrimPrefix(value, backDesignPrefix))
+	if err != nil {
+		fyne.LogError("Unable to load card back design", err)
+		return nil
+	}
+	return faces.NewBack(d)
+}
+
+// loadBackgroundURI reads the image stored at the URI of the table background preference
+func loadBackgroundURI(uri string) (fyne.Resource, error) {
+	u, err := storage.ParseURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := storage.Reader(u)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return fyne.NewStaticResource(u.Name(), data), nil
+}
+
+// loadPackURI loads the pack for a stored preference value.
+// This is either the name of a built in pack or the URI of an imported one.
+func loadPackURI(uri string) (*faces.Pack, error) {
+	if pack := faces.BuiltinPack(uri); pack != nil {
+		return pack, nil
+	}
+
+	u, err := storage.ParseURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return faces.LoadPack(u)
+}
+
+// showSettings opens a dialog to change the appearance of the table
+func showSettings(t *Table, w fyne.Window) {
+	prefs := fyne.CurrentApp().Preferences()
+
+	deck := widget.NewSelect(nil, nil)
+	refreshDecks := func() {
+		builtin := faces.BuiltinPacks()
+		deck.SetOptions(append(append([]string{}, builtin...), prefs.StringList(prefDeckPacks)...))
+
+		if current := prefs.String(prefDeckPack); current != "" {
+			deck.SetSelected(current)
+		} else {
+			deck.SetSelected(builtin[0])
+		}
+	}
+	refreshDecks()
+	deck.OnChanged = func(uri string) {
+		if uri == faces.BuiltinPacks()[0] {
+			faces.SetPack(nil)
+			prefs.SetString(prefDeckPack, "")
+			t.Refresh()
+			return
+		}
+
+		pack, err := loadPackURI(uri)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		faces.SetPack(pack)
+		prefs.SetString(prefDeckPack, uri)
+		t.Refresh()
+	}
+
+	addPack := func(u fyne.URI) {
+		if _, err := faces.LoadPack(u); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+
+		packs := prefs.StringList(prefDeckPacks)
+		for _, existing := range packs {
+			if existing == u.String() {
+				deck.SetSelected(existing)
+				return
+			}
+		}
+		prefs.SetStringList(prefDeckPacks, append(packs, u.String()))
+		refreshDecks()
+		deck.SetSelected(u.String())
+	}
+	importFolder := widget.NewButton("Import Folder...", func() {
+		dialog.ShowFolderOpen(func(dir fyne.ListableURI, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if dir != nil {
+				addPack(dir)
+			}
+		}, w)
+	})
+	importZip := widget.NewButton("Import Zip...", func() {
+		d := dialog.NewFileOpen(func(r fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if r != nil {
+				_ = r.Close()
+				addPack(r.URI())
+			}
+		}, w)
+		d.SetFilter(storage.NewExtensionFileFilter([]string{".zip"}))
+		d.Show()
+	})
+
+	leftHanded := widget.NewCheck("Left handed", func(on bool) {
+		prefs.SetBool(prefLeftHanded, on)
+	})
+	leftHanded.SetChecked(prefs.Bool(prefLeftHanded))
+	smartTap := widget.NewCheck("Move cards with a single tap", func(on bool) {
+		prefs.SetBool(prefSmartTap, on)
+	})
+	smartTap.SetChecked(prefs.Bool(prefSmartTap))
+	autoPlay := widget.NewCheck("Play safe cards to the foundations", func(on bool) {
+		prefs.SetBool(prefAutoPlay, on)
+	})
+	autoPlay.SetChecked(prefs.Bool(prefAutoPlay))
+	celebration := widget.NewSelect(winAnimationNames(), func(name string) {
+		prefs.SetString(prefWinAnimation, name)
+	})
+	celebration.SetSelected(winAnimationForName(prefs.String(prefWinAnimation)).name)
+
+	volume := widget.NewSlider(0, 1)
+	volume.Step = 0.1
+	volume.SetValue(prefs.FloatWithFallback(prefSoundVolume, defaultVolume))
+	volume.OnChangeEnded = func(v float64) {
+		prefs.SetFloat(prefSoundVolume, v)
+		t.sound.Play(SoundDrop)
+	}
+	mute := widget.NewCheck("Mute", func(on bool) {
+		prefs.SetBool(prefSoundMuted, on)
+	})
+	mute.SetChecked(prefs.Bool(prefSoundMuted))
+
+	form := widget.NewForm(
+		widget.NewFormItem("Card deck", container.NewBorder(nil, nil, nil,
+			container.NewHBox(importFolder, importZip), deck)),
+		widget.NewFormItem("Card back", newBackChooser(t, w)),
+		widget.NewFormItem("Table felt", newFeltChooser(w)),
+		widget.NewFormItem("Background", newBackgroundChooser(w)),
+		widget.NewFormItem("Theme", newVariantChooser()),
+		widget.NewFormItem("Layout", leftHanded),
+		widget.NewFormItem("Controls", container.NewVBox(smartTap, autoPlay)),
+		widget.NewFormItem("Celebration", celebration),
+		widget.NewFormItem("Sound", container.NewBorder(nil, nil, nil, mute, volume)))
+	dialog.ShowCustom("Settings", "Close", form, w)
+}
+
+// newFeltChooser returns a selection of felt colours and a button to pick a custom colour
+func newFeltChooser(w fyne.Window) fyne.CanvasObject {
+	a := fyne.CurrentApp()
+	prefs := a.Preferences()
+	felt := widget.NewSelect(append(append([]string{}, feltNames...), customFeltName), nil)
+
+	current := prefs.String(prefFelt)
+	if current == "" {
+		current = feltNames[0]
+	}
+	if _, ok := feltColors[current]; ok {
+		felt.SetSelected(current)
+	} else {
+		felt.SetSelected(customFeltName)
+	}
+
+	setFelt := func(value string) {
+		prefs.SetString(prefFelt, value)
+		a.Settings().SetTheme(loadTheme(prefs))
+	}
+	pick := func() {
+		picker := dialog.NewColorPicker("Table Felt Colour", "", func(c color.Color) {
+			setFelt(faces.HexColor(color.NRGBAModel.Convert(c).(color.NRGBA)))
+			felt.SetSelected(customFeltName)
+		}, w)
+		picker.Advanced = true
+		picker.SetColor(feltForPreference(prefs.String(prefFelt)))
+		picker.Show()
+	}
+	felt.OnChanged = func(name string) {
+		if name != customFeltName {
+			setFelt(name)
+			return
+		}
+		if _, ok := feltColors[prefs.String(prefFelt)]; ok || prefs.String(prefFelt) == "" {
+			pick()
+		}
+	}
+	return container.NewBorder(nil, nil, nil, widget.NewButton("Choose...", pick), felt)
+}
+
+// newBackgroundChooser returns buttons to choose, or remove, an image shown behind the cards
+func newBackgroundChooser(w fyne.Window) fyne.CanvasObject {
+	prefs := fyne.CurrentApp().Preferences()
+	name := widget.NewLabel("None")
+	if uri, err := storage.ParseURI(prefs.String(prefBackground)); err == nil {
+		name.SetText(uri.Name())
+	}
+
+	choose := widget.NewButton("Choose...", func() {
+		d := dialog.NewFileOpen(func(r fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if r == nil {
+				return
+			}
+			_ = r.Close()
+
+			if _, err := loadBackgroundURI(r.URI().String()); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			prefs.SetString(prefBackground, r.URI().String())
+			name.SetText(r.URI().Name())
+		}, w)
+		d.SetFilter(storage.NewExtensionFileFilter([]string{".png", ".jpg", ".jpeg", ".svg"}))
+		d.Show()
+	})
+	remove := widget.NewButton("Clear", func() {
+		prefs.SetString(prefBackground, "")
+		name.SetText("None")
+	})
+	return container.NewBorder(nil, nil, nil, container.NewHBox(choose, remove), name)
+}
+
+// newVariantChooser returns a selection of light, dark or system appearance for the toolbar and dialogs
+func newVariantChooser() fyne.CanvasObject {
+	a := fyne.CurrentApp()
+	prefs := a.Preferences()
+	variant := widget.NewSelect(variantNames, func(name string) {
+		prefs.SetString(prefVariant, name)
+		a.Settings().SetTheme(loadTheme(prefs))
+	})
+	variant.SetSelected(prefs.StringWithFallback(prefVariant, variantDark))
+	return variant
+}
+
+// newBackChooser returns a selection of card backs and a button to design a custom back
+func newBackChooser(t *Table, w fyne.Window) fyne.CanvasObject {
+	prefs := fyne.CurrentApp().Preferences()
+	options := append([]string{defaultBackName}, faces.BuiltinBacks()...)
+	back := widget.NewSelect(append(options, customBackName), nil)
+
+	current := prefs.String(prefCardBack)
+	switch {
+	case current == "":
+		back.SetSelected(defaultBackName)
+	case strings.HasPrefix(current, backDesignPrefix):
+		back.SetSelected(customBackName)
+	default:
+		back.SetSelected(current)
+	}
+
+	setBack := func(value string) {
+		prefs.SetString(prefCardBack, value)
+		faces.SetBack(backForPreference(value))
+		t.Refresh()
+	}
+	design := func() {
+		d := defaultBackDesign
+		if saved, err := faces.ParseBackDesign(prefs.String(prefCustomBack)); err == nil {
+			d = saved
+		}
+		showBackDesigner(d, func(d faces.BackDesign) {
+			prefs.SetString(prefCustomBack, d.String())
+			setBack(backDesignPrefix + d.String())
+			back.SetSelected(customBackName)
+		}, w)
+	}
+	back.OnChanged = func(name string) {
+		switch name {
+		case defaultBackName:
+			setBack("")
+		case customBackName:
+			if saved := prefs.String(prefCustomBack); saved != "" {
+				setBack(backDesignPrefix + saved)
+			} else {
+				design()
+			}
+		default:
+			setBack(name)
+		}
+	}
+	return container.NewBorder(nil, nil, nil, widget.NewButton("Design...", design), back)
+}
+
+// showBackDesigner opens a dialog to generate a card back from a colour, pattern and emblem, which may be a picture
+func showBackDesigner(design faces.BackDesign, onSave func(faces.BackDesign), w fyne.Window) {
+	preview := canvas.NewImageFromResource(faces.NewBack(design))
+	preview.FillMode = canvas.ImageFillContain
+	preview.SetMinSize(fyne.NewSize(100, 100*cardRatio))
+	update := func() {
+		preview.Resource = faces.NewBack(design)
+		preview.Refresh()
+	}
+
+	colour := widget.NewButton("Choose...", func() {
+		picker := dialog.NewColorPicker("Card Back Colour", "", func(c color.Color) {
+			design.Color = color.NRGBAModel.Convert(c).(color.NRGBA)
+			update()
+		}, w)
+		picker.Advanced = true
+		picker.SetColor(design.Color)
+		picker.Show()
+	})
+	pattern := widget.NewSelect(faces.PatternNames(), func(name string) {
+		design.Pattern = faces.BackPattern(indexOfString(faces.PatternNames(), name))
+		update()
+	})
+	pattern.SetSelected(design.Pattern.String())
+
+	path := widget.NewEntry()
+	path.SetPlaceHolder("SVG path within 100x100, like M50 0 L100 100 L0 100 Z")
+	path.SetText(design.EmblemPath)
+	path.Validator = func(s string) error {
+		if design.Emblem == faces.EmblemCustom && !faces.ValidEmblemPath(s) {
+			return errInvalidEmblem
+		}
+		return nil
+	}
+	path.OnChanged = func(s string) {
+		design.EmblemPath = s
+		update()
+	}
+	picture := widget.NewLabel("None")
+	if u, err := storage.ParseURI(design.EmblemURI); err == nil {
+		picture.SetText(u.Name())
+	}
+	choose := widget.NewButton("Choose...", func() {
+		d := dialog.NewFileOpen(func(r fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if r == nil {
+				return
+			}
+			_ = r.Close()
+
+			design.EmblemURI = r.URI().String()
+			picture.SetText(r.URI().Name())
+			update()
+		}, w)
+		d.SetFilter(storage.NewExtensionFileFilter([]string{".png", ".jpg", ".jpeg"}))
+		d.Show()
+	})
+
+	emblem := widget.NewSelect(faces.EmblemNames(), func(name string) {
+		design.Emblem = faces.BackEmblem(indexOfString(faces.EmblemNames(), name))
+		if design.Emblem == faces.EmblemCustom {
+			path.Enable()
+		} else {
+			path.Disable()
+		}
+		if design.Emblem == faces.EmblemImage {
+			choose.Enable()
+		} else {
+			choose.Disable()
+		}
+		_ = path.Validate()
+		update()
+	})
+	emblem.SetSelected(design.Emblem.String())
+
+	form := widget.NewForm(
+		widget.NewFormItem("Colour", colour),
+		widget.NewFormItem("Pattern", pattern),
+		widget.NewFormItem("Emblem", emblem),
+		widget.NewFormItem("Emblem path", path),
+		widget.NewFormItem("Emblem image", container.NewBorder(nil, nil, nil, choose, picture)))
+	content := container.NewBorder(nil, nil, nil, container.NewCenter(preview), form)
+	dialog.ShowCustomConfirm("Card Back Designer", "Save", "Cancel", content, func(ok bool) {
+		if !ok {
+			return
+		}
+		if design.Emblem == faces.EmblemCustom && !faces.ValidEmblemPath(design.EmblemPath) ||
+			design.Emblem == faces.EmblemImage && design.EmblemURI == "" {
+			design.Emblem = faces.EmblemNone
+		}
+		onSave(design)
+	}, w)
+}
+
+func indexOfString(list []string, item string) int {
+	for i, s := range list {
+		if s == item {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fyne-io/solitaire/faces"
+)
+
+func TestBackForPreference(t *testing.T) {
+	assert.Nil(t, backForPreference(""))
+	assert.Equal(t, faces.BuiltinBack(faces.ClassicBack), backForPreference(faces.ClassicBack))
+
+	design := backDesignPrefix + defaultBackDesign.String()
+	assert.Equal(t, faces.NewBack(defaultBackDesign).Name(), backForPreference(design).Name())
+	assert.Nil(t, backForPreference(backDesignPrefix+"broken"))
+}
+
+func TestLoadPackURI_Builtin(t *testing.T) {
+	pack, err := loadPackURI(faces.HighContrastPack)
+	assert.NoError(t, err)
+	assert.Equal(t, faces.BuiltinPack(faces.HighContrastPack), pack)
+}
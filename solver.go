@@ -0,0 +1,421 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// SolveResult describes the outcome of searching for a solution to a deal
+type SolveResult int
+
+const (
+	// SolveFound means a sequence of moves that wins the game was found
+	SolveFound SolveResult = iota
+	// SolveNotFound means the search finished without finding a solution.
+	// The search skips some unlikely moves so this does not prove the deal cannot be won.
+	SolveNotFound
+	// SolveBudgetExceeded means the search was stopped before it could finish
+	SolveBudgetExceeded
+)
+
+// SolverMove is a single step in a solution found by the solver.
+// Draws from (or recycling of) the stock have From set to StockPile and a nil Card.
+type SolverMove struct {
+	Card     *Card
+	From, To PileID
+}
+
+// solverState is a compact copy of a game used by the solver.
+// Cards are encoded as suit*13 + value-1.
+type solverState struct {
+	tableau [TableauCount][]byte
+	down    [TableauCount]int // face down cards at the start of each tableau column
+	found   [4]int            // the top value on the foundation of each suit, 0 if empty
+
+	stock, waste []byte // the next card to be drawn is stock[0], the top of the waste is last
+	redeals      int
+}
+
+type solver struct {
+	ctx       context.Context
+	maxNodes  int
+	maxPasses int
+
+	nodes   int
+	visited map[string]bool
+	path    []SolverMove
+	aborted bool
+}
+
+// Solve searches for a sequence of moves that wins the game from its current position.
+// The search stops after maxNodes positions have been examined or when the context is done.
+// The game passed in is not modified.
+func Solve(ctx context.Context, g *Game, maxNodes int) (SolveResult, []SolverMove) {
+	s := &solver{ctx: ctx, maxNodes: maxNodes, maxPasses: g.MaxPasses, visited: make(map[string]bool)}
+	state := newSolverState(g)
+
+	if s.search(state) {
+		assignFoundations(g, s.path)
+		return SolveFound, s.path
+	}
+	if s.aborted {
+		return SolveBudgetExceeded, nil
+	}
+	return SolveNotFound, nil
+}
+
+// assignFoundations updates the moves to foundations, which the search indexes by suit,
+// to use the foundation pile that each suit will be built on in the game.
+func assignFoundations(g *Game, moves []SolverMove) {
+	bySuit := make(map[int]int)
+	empty := []int{}
+	for i, b := range g.Foundations {
+		if top := b.Top(); top != nil {
+			bySuit[int(top.Suit)] = i
+		} else {
+			empty = append(empty, i)
+		}
+	}
+
+	for i, m := range moves {
+		if m.To.Type != PileFoundation {
+			continue
+		}
+
+		suit := int(m.Card.Suit)
+		index, ok := bySuit[suit]
+		if !ok {
+			index, empty = empty[0], empty[1:]
+			bySuit[suit] = index
+		}
+		moves[i].To = FoundationPile(index)
+	}
+}
+
+func encodeCard(c *Card) byte {
+	return byte(int(c.Suit)*ValueKing + c.Value - 1)
+}
+
+func decodeCard(b byte) *Card {
+	return &Card{Value: int(b)%ValueKing + 1, Suit: Suit(int(b) / ValueKing), FaceUp: true}
+}
+
+func solverValue(b byte) int {
+	return int(b)%ValueKing + 1
+}
+
+func solverSuit(b byte) int {
+	return int(b) / ValueKing
+}
+
+func solverRed(b byte) bool {
+	s := Suit(solverSuit(b))
+	return s == SuitDiamonds || s == SuitHearts
+}
+
+func newSolverState(g *Game) *solverState {
+	s := &solverState{redeals: g.Redeals}
+	for i, stack := range g.Tableau {
+		for _, c := range stack.Cards {
+			if !c.FaceUp {
+				s.down[i]++
+			}
+			s.tableau[i] = append(s.tableau[i], encodeCard(c))
+		}
+	}
+	for _, b := range g.Foundations {
+		if top := b.Top(); top != nil {
+			s.found[top.Suit] = top.Value
+		}
+	}
+	for _, c := range g.Hand.Cards {
+		s.stock = append(s.stock, encodeCard(c))
+	}
+	for _, c := range g.Drawn.Cards {
+		s.waste = append(s.waste, encodeCard(c))
+	}
+
+	return s
+}
+
+func (s *solverState) clone() *solverState {
+	c := &solverState{down: s.down, found: s.found, redeals: s.redeals}
+	for i, col := range s.tableau {
+		c.tableau[i] = append([]byte(nil), col...)
+	}
+	c.stock = append([]byte(nil), s.stock...)
+	c.waste = append([]byte(nil), s.waste...)
+	return c
+}
+
+func (s *solverState) won() bool {
+	for _, v := range s.found {
+		if v != ValueKing {
+			return false
+		}
+	}
+	return true
+}
+
+// key returns a string that identifies equivalent positions.
+// Tableau columns are sorted as their order does not affect the outcome.
+func (s *solverState) key(withRedeals bool) string {
+	cols := make([]string, len(s.tableau))
+	for i, col := range s.tableau {
+		cols[i] = string(rune('a'+s.down[i])) + string(col)
+	}
+	sort.Strings(cols)
+
+	b := &strings.Builder{}
+	for _, col := range cols {
+		b.WriteString(col)
+		b.WriteByte(0xff)
+	}
+	b.Write(s.stock)
+	b.WriteByte(0xfe)
+	b.Write(s.waste)
+	if withRedeals {
+		b.WriteByte(byte(s.redeals))
+	}
+	return b.String()
+}
+
+func (s *solverState) canFound(c byte) bool {
+	return s.found[solverSuit(c)] == solverValue(c)-1
+}
+
+// safeToFound returns true if moving the card to a foundation can never make the game harder to win
+func (s *solverState) safeToFound(c byte) bool {
+	if !s.canFound(c) {
+		return false
+	}
+	v := solverValue(c)
+	if v <= 2 {
+		return true
+	}
+
+	for suit, top := range s.found {
+		if solverRed(byte(suit*ValueKing)) != solverRed(c) && top < v-1 {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *solverState) canStack(col int, c byte) bool {
+	cards := s.tableau[col]
+	if len(cards) == 0 {
+		return solverValue(c) == ValueKing
+	}
+
+	top := cards[len(cards)-1]
+	return solverRed(top) != solverRed(c) && solverValue(top) == solverValue(c)+1
+}
+
+// popTableau removes cards from the column starting at index, turning up any card now on top
+func (s *solverState) popTableau(col, index int) []byte {
+	moved := append([]byte(nil), s.tableau[col][index:]...)
+	s.tableau[col] = s.tableau[col][:index]
+	if n := len(s.tableau[col]); n > 0 && s.down[col] >= n {
+		s.down[col] = n - 1
+	}
+	return moved
+}
+
+func (s *solver) search(state *solverState) bool {
+	if s.aborted {
+		return false
+	}
+	s.nodes++
+	if s.nodes > s.maxNodes {
+		s.aborted = true
+		return false
+	}
+	if s.nodes%1024 == 0 && s.ctx.Err() != nil {
+		s.aborted = true
+		return false
+	}
+
+	depth := len(s.path)
+	state = s.applySafeMoves(state.clone())
+	if state.won() {
+		return true
+	}
+
+	key := state.key(s.maxPasses != PassesUnlimited)
+	if s.visited[key] {
+		s.path = s.path[:depth]
+		return false
+	}
+	s.visited[key] = true
+
+	for _, next := range s.moves(state) {
+		s.path = append(s.path, next.move)
+		if s.search(next.state) {
+			return true
+		}
+		if s.aborted {
+			return false
+		}
+		s.path = s.path[:depth]
+	}
+
+	s.path = s.path[:depth]
+	return false
+}
+
+// applySafeMoves plays every card that can safely go to a foundation, recording them on the path
+func (s *solver) applySafeMoves(state *solverState) *solverState {
+	for moved := true; moved; {
+		moved = false
+		if n := len(state.waste); n > 0 && state.safeToFound(state.waste[n-1]) {
+			c := state.waste[n-1]
+			state.waste = state.waste[:n-1]
+			state.found[solverSuit(c)]++
+			s.path = append(s.path, SolverMove{Card: decodeCard(c), From: WastePile, To: FoundationPile(solverSuit(c))})
+			moved = true
+		}
+
+		for i, col := range state.tableau {
+			if n := len(col); n > 0 && state.safeToFound(col[n-1]) {
+				c := col[n-1]
+				state.popTableau(i, n-1)
+				state.found[solverSuit(c)]++
+				s.path = append(s.path, SolverMove{Card: decodeCard(c), From: TableauPile(i), To: FoundationPile(solverSuit(c))})
+				moved = true
+			}
+		}
+	}
+
+	return state
+}
+
+type solverStep struct {
+	move  SolverMove
+	state *solverState
+}
+
+// moves lists the positions reachable in one move, in the order they should be tried
+func (s *solver) moves(state *solverState) []solverStep {
+	var steps []solverStep
+
+	// cards that can go to the foundations
+	if n := len(state.waste); n > 0 && state.canFound(state.waste[n-1]) {
+		c := state.waste[n-1]
+		next := state.clone()
+		next.waste = next.waste[:n-1]
+		next.found[solverSuit(c)]++
+		steps = append(steps, solverStep{SolverMove{decodeCard(c), WastePile, FoundationPile(solverSuit(c))}, next})
+	}
+	for i, col := range state.tableau {
+		if n := len(col); n > 0 && state.canFound(col[n-1]) {
+			c := col[n-1]
+			next := state.clone()
+			next.popTableau(i, n-1)
+			next.found[solverSuit(c)]++
+			steps = append(steps, solverStep{SolverMove{decodeCard(c), TableauPile(i), FoundationPile(solverSuit(c))}, next})
+		}
+	}
+
+	// tableau moves that turn up a card or empty a column, then moves that free a card for the foundations
+	var freeing []solverStep
+	for from, col := range state.tableau {
+		for index := state.down[from]; index < len(col); index++ {
+			reveals := index == state.down[from]
+			frees := index > 0 && index > state.down[from] && state.canFound(col[index-1])
+			if !reveals && !frees {
+				continue
+			}
+
+			triedSpace := false
+			for to := range state.tableau {
+				if to == from || !state.canStack(to, col[index]) {
+					continue
+				}
+				if len(state.tableau[to]) == 0 {
+					if index == 0 || triedSpace {
+						continue // moving a whole column into a space, or to a second space, achieves nothing
+					}
+					triedSpace = true
+				}
+
+				next := state.clone()
+				next.tableau[to] = append(next.tableau[to], next.popTableau(from, index)...)
+				step := solverStep{SolverMove{decodeCard(col[index]), TableauPile(from), TableauPile(to)}, next}
+				if reveals {
+					steps = append(steps, step)
+				} else {
+					freeing = append(freeing, step)
+				}
+			}
+		}
+	}
+
+	// the waste card onto the tableau
+	if n := len(state.waste); n > 0 {
+		c := state.waste[n-1]
+		triedSpace := false
+		for to := range state.tableau {
+			if !state.canStack(to, c) {
+				continue
+			}
+			if len(state.tableau[to]) == 0 {
+				if triedSpace {
+					continue
+				}
+				triedSpace = true
+			}
+
+			next := state.clone()
+			next.waste = next.waste[:n-1]
+			next.tableau[to] = append(next.tableau[to], c)
+			steps = append(steps, solverStep{SolverMove{decodeCard(c), WastePile, TableauPile(to)}, next})
+		}
+	}
+	steps = append(steps, freeing...)
+
+	// finally draw from the stock, or turn the waste back over
+	if len(state.stock) > 0 {
+		next := state.clone()
+		count := 3
+		if len(next.stock) < count {
+			count = len(next.stock)
+		}
+		next.waste = append(next.waste, next.stock[:count]...)
+		next.stock = next.stock[count:]
+		steps = append(steps, solverStep{SolverMove{From: StockPile, To: WastePile}, next})
+	} else if len(state.waste) > 0 && (s.maxPasses == PassesUnlimited || state.redeals < s.maxPasses-1) {
+		next := state.clone()
+		next.stock, next.waste = next.waste, nil
+		next.redeals++
+		steps = append(steps, solverStep{SolverMove{From: StockPile, To: WastePile}, next})
+	}
+
+	return steps
+}
+
+// NewWinnableGame deals games, starting from the seed passed and counting up, until one is found
+// that the solver can win within maxNodes. At most attempts deals will be tried and the search stops
+// early if the context is done. If no winnable deal is found the game for the first seed is returned
+// along with false. The progress callback, if not nil, is called with the number of deals tried.
+// A winnable deal has FixedStock set, as shuffling the stock could make it unwinnable.
+func NewWinnableGame(ctx context.Context, seed int64, maxPasses, attempts, maxNodes int, progress func(int)) (*Game, bool) {
+	for i := 0; i < attempts && ctx.Err() == nil; i++ {
+		g := NewGameFromSeed(seed + int64(i))
+		g.MaxPasses = maxPasses
+
+		result, _ := Solve(ctx, g, maxNodes)
+		if progress != nil {
+			progress(i + 1)
+		}
+		if result == SolveFound {
+			g.FixedStock = true
+			return g, true
+		}
+	}
+
+	g := NewGameFromSeed(seed)
+	g.MaxPasses = maxPasses
+	return g, false
+}
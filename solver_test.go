@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newNearlyWonGame returns a game with every card on the foundations except the kings, which are on the tableau
+func newNearlyWonGame() *Game {
+	g := NewGameFromSeed(1)
+	g.Hand = &Deck{}
+	g.Drawn = &Deck{}
+	for i := range g.Tableau {
+		g.Tableau[i].Cards = nil
+	}
+	for suit := 0; suit < 4; suit++ {
+		g.Foundations[suit].Cards = nil
+		for value := 1; value < ValueKing; value++ {
+			g.Foundations[suit].Push(NewCard(value, Suit(suit)))
+		}
+
+		king := NewCard(ValueKing, Suit(suit))
+		king.FaceUp = true
+		g.Tableau[suit].Push(king)
+	}
+	return g
+}
+
+func TestSolve_Trivial(t *testing.T) {
+	g := newNearlyWonGame()
+
+	result, moves := Solve(context.Background(), g, 100)
+	assert.Equal(t, SolveFound, result)
+	assert.Equal(t, 4, len(moves))
+	assert.Equal(t, TableauPile(0), moves[0].From)
+	assert.Equal(t, PileFoundation, moves[0].To.Type)
+}
+
+func TestSolve_FromStock(t *testing.T) {
+	g := newNearlyWonGame()
+	king := g.Tableau[2].Pop()
+	king.FaceUp = false
+	g.Hand.Push(king)
+
+	result, moves := Solve(context.Background(), g, 100)
+	assert.Equal(t, SolveFound, result)
+	assert.Equal(t, 5, len(moves))
+	assert.Contains(t, moves, SolverMove{From: StockPile, To: WastePile})
+}
+
+func TestSolve_Blocked(t *testing.T) {
+	g := newNearlyWonGame()
+	for i := range g.Tableau {
+		g.Tableau[i].Cards = nil
+	}
+	for suit := range g.Foundations {
+		if Suit(suit) != SuitClubs {
+			g.Foundations[suit].Push(NewCard(ValueKing, Suit(suit)))
+		}
+	}
+
+	// the ace of clubs is under the two, which has nowhere to go
+	two := NewCard(2, SuitClubs)
+	two.FaceUp = true
+	g.Foundations[SuitClubs].Cards = nil
+	g.Tableau[0].Cards = []*Card{NewCard(1, SuitClubs), two}
+	for value := 3; value <= ValueKing; value++ {
+		g.Hand.Push(NewCard(value, SuitClubs))
+	}
+
+	result, _ := Solve(context.Background(), g, 10000)
+	assert.Equal(t, SolveNotFound, result)
+}
+
+func TestSolve_Budget(t *testing.T) {
+	g := newTestGame()
+
+	result, moves := Solve(context.Background(), g, 10)
+	assert.Equal(t, SolveBudgetExceeded, result)
+	assert.Nil(t, moves)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, _ = Solve(ctx, g, 1<<30)
+	assert.Equal(t, SolveBudgetExceeded, result)
+}
+
+func TestSolve_Replay(t *testing.T) {
+	for seed := int64(1); seed < 20; seed++ {
+		g := NewGameFromSeed(seed)
+		result, moves := Solve(context.Background(), g, 50000)
+		if result != SolveFound {
+			continue
+		}
+
+		won := false
+		g.OnWin = func() {
+			won = true
+		}
+		for _, m := range moves {
+			if m.From == StockPile {
+				g.DrawThree()
+				continue
+			}
+			g.MoveCard(m.Card, m.To)
+		}
+		assert.True(t, won, "solution for seed %d did not win", seed)
+		return
+	}
+
+	t.Error("no solvable deal found in the first seeds")
+}
+
+func TestNewWinnableGame(t *testing.T) {
+	tried := 0
+	g, ok := NewWinnableGame(context.Background(), 1, PassesUnlimited, 20, 50000, func(n int) {
+		tried = n
+	})
+	assert.True(t, ok)
+	assert.True(t, tried > 0)
+	assert.False(t, g.CanShuffleStock())
+	result, _ := Solve(context.Background(), g, 50000)
+	assert.Equal(t, SolveFound, result)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g, ok = NewWinnableGame(ctx, 1, PassesOne, 20, 50000, nil)
+	assert.False(t, ok)
+	assert.Equal(t, int64(1), g.Seed)
+	assert.Equal(t, PassesOne, g.MaxPasses)
+	assert.True(t, g.CanShuffleStock())
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/rand"
+
+	"fyne.io/fyne/v2"
+)
+
+const (
+	prefSoundVolume = "sound.volume"
+	prefSoundMuted  = "sound.muted"
+
+	defaultVolume = 0.7
+	sampleRate    = 22050
+)
+
+// Sound identifies one of the short effects played as the game is played
+type Sound int
+
+const (
+	// SoundPickUp is played when a card is selected or starts being dragged
+	SoundPickUp Sound = iota
+	// SoundDrop is played when cards are placed on a pile
+	SoundDrop
+	// SoundIllegal is played when a move is not allowed
+	SoundIllegal
+	// SoundFlip is played when a card is turned over, including draws from the stock
+	SoundFlip
+	// SoundRecycle is played when the waste is turned back into the stock
+	SoundRecycle
+	// SoundWin is played when the game is won
+	SoundWin
+)
+
+// AudioBackend plays sound effects, with a volume from 0 to 1
+type AudioBackend interface {
+	Play(s Sound, volume float64)
+}
+
+// silentBackend is an AudioBackend that plays nothing, for tests and devices without audio
+type silentBackend struct{}
+
+func (silentBackend) Play(Sound, float64) {}
+
+// soundPlayer applies the volume and mute preferences before passing sounds to a backend
+type soundPlayer struct {
+	backend AudioBackend
+	prefs   fyne.Preferences
+}
+
+func newSoundPlayer(backend AudioBackend, prefs fyne.Preferences) *soundPlayer {
+	return &soundPlayer{backend: backend, prefs: prefs}
+}
+
+// Play sends the sound to the backend unless sound is muted
+func (p *soundPlayer) Play(s Sound) {
+	if p == nil || p.prefs.Bool(prefSoundMuted) {
+		return
+	}
+
+	volume := p.prefs.FloatWithFallback(prefSoundVolume, defaultVolume)
+	if volume <= 0 {
+		return
+	}
+	p.backend.Play(s, math.Min(volume, 1))
+}
+
+// PlayEvent plays the sound for a game event
+func (p *soundPlayer) PlayEvent(e GameEvent) {
+	switch e {
+	case EventDraw, EventFlip:
+		p.Play(SoundFlip)
+	case EventMove:
+		p.Play(SoundDrop)
+	case EventRecycle:
+		p.Play(SoundRecycle)
+	case EventIllegal:
+		p.Play(SoundIllegal)
+	case EventWin:
+		p.Play(SoundWin)
+	}
+}
+
+// tone is a section of a synthesised sound
+type tone struct {
+	freq, endFreq float64 // frequency in Hz, sweeping to endFreq, or 0 for noise
+	seconds       float64
+	square        bool
+}
+
+var soundTones = map[Sound][]tone{
+	SoundPickUp:  {{freq: 600, endFreq: 900, seconds: 0.05}},
+	SoundDrop:    {{freq: 220, endFreq: 140, seconds: 0.08}},
+	SoundIllegal: {{freq: 150, endFreq: 150, seconds: 0.09, square: true}, {freq: 120, endFreq: 120, seconds: 0.12, square: true}},
+	SoundFlip:    {{seconds: 0.04}},
+	SoundRecycle: {{seconds: 0.12}, {seconds: 0.12}},
+	SoundWin: {{freq: 523, endFreq: 523, seconds: 0.12}, {freq: 659, endFreq: 659, seconds: 0.12},
+		{freq: 784, endFreq: 784, seconds: 0.12}, {freq: 1047, endFreq: 1047, seconds: 0.3}},
+}
+
+// soundWAV synthesises a sound at the specified volume as 16 bit mono WAV data
+func soundWAV(s Sound, volume float64) []byte {
+	noise := rand.New(rand.NewSource(int64(s)))
+	var samples []int16
+	for _, t := range soundTones[s] {
+		count := int(t.seconds * sampleRate)
+		phase := 0.0
+		for i := 0; i < count; i++ {
+			progress := float64(i) / float64(count)
+			envelope := math.Min(1, float64(i)/(sampleRate*0.005)) * (1 - progress) // fast attack and fade out
+
+			v := noise.Float64()*2 - 1
+			if t.freq > 0 {
+				phase += 2 * math.Pi * (t.freq + (t.endFreq-t.freq)*progress) / sampleRate
+				v = math.Sin(phase)
+				if t.square {
+					v = math.Copysign(0.6, v)
+				}
+			}
+			samples = append(samples, int16(v*envelope*volume*math.MaxInt16*0.8))
+		}
+	}
+
+	b := &bytes.Buffer{}
+	dataSize := uint32(len(samples) * 2)
+	b.WriteString("RIFF")
+	_ = binary.Write(b, binary.LittleEndian, 36+dataSize)
+	b.WriteString("WAVEfmt ")
+	_ = binary.Write(b, binary.LittleEndian, []uint32{16})
+	_ = binary.Write(b, binary.LittleEndian, []uint16{1, 1}) // PCM, mono
+	_ = binary.Write(b, binary.LittleEndian, []uint32{sampleRate, sampleRate * 2})
+	_ = binary.Write(b, binary.LittleEndian, []uint16{2, 16}) // block align, bits per sample
+	b.WriteString("data")
+	_ = binary.Write(b, binary.LittleEndian, dataSize)
+	_ = binary.Write(b, binary.LittleEndian, samples)
+	return b.Bytes()
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingBackend struct {
+	sounds  []Sound
+	volumes []float64
+}
+
+func (r *recordingBackend) Play(s Sound, volume float64) {
+	r.sounds = append(r.sounds, s)
+	r.volumes = append(r.volumes, volume)
+}
+
+func TestSoundPlayer_Play(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	backend := &recordingBackend{}
+	player := newSoundPlayer(backend, a.Preferences())
+
+	player.Play(SoundDrop)
+	assert.Equal(t, []Sound{SoundDrop}, backend.sounds)
+	assert.Equal(t, []float64{defaultVolume}, backend.volumes)
+
+	a.Preferences().SetFloat(prefSoundVolume, 0.25)
+	player.PlayEvent(EventWin)
+	assert.Equal(t, []Sound{SoundDrop, SoundWin}, backend.sounds)
+	assert.Equal(t, 0.25, backend.volumes[1])
+
+	a.Preferences().SetBool(prefSoundMuted, true)
+	player.Play(SoundFlip)
+	assert.Len(t, backend.sounds, 2)
+
+	var silent *soundPlayer
+	silent.Play(SoundFlip) // a nil player is silent
+}
+
+func TestSoundWAV(t *testing.T) {
+	for s := SoundPickUp; s <= SoundWin; s++ {
+		data := soundWAV(s, 1)
+		assert.Equal(t, "RIFF", string(data[:4]))
+		assert.Equal(t, "WAVE", string(data[8:12]))
+		size := binary.LittleEndian.Uint32(data[40:44])
+		assert.Equal(t, int(size), len(data)-44)
+		assert.Greater(t, size, uint32(0))
+	}
+}
+
+func TestSilentBackend(t *testing.T) {
+	silentBackend{}.Play(SoundWin, 1)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+const (
+	prefStatsPlayed    = "stats.played"
+	prefStatsWon       = "stats.won"
+	prefStatsBestTime  = "stats.best_time"
+	prefStatsTotalTime = "stats.total_time"
+)
+
+// statistics is the record of games played, stored in the app preferences.
+// Times are stored in milliseconds.
+type statistics struct {
+	Played, Won         int
+	BestTime, TotalTime time.Duration
+}
+
+func loadStatistics(p fyne.Preferences) *statistics {
+	return &statistics{
+		Played:    p.Int(prefStatsPlayed),
+		Won:       p.Int(prefStatsWon),
+		BestTime:  time.Duration(p.Int(prefStatsBestTime)) * time.Millisecond,
+		TotalTime: time.Duration(p.Int(prefStatsTotalTime)) * time.Millisecond,
+	}
+}
+
+func (s *statistics) save(p fyne.Preferences) {
+	p.SetInt(prefStatsPlayed, s.Played)
+	p.SetInt(prefStatsWon, s.Won)
+	p.SetInt(prefStatsBestTime, int(s.BestTime/time.Millisecond))
+	p.SetInt(prefStatsTotalTime, int(s.TotalTime/time.Millisecond))
+}
+
+// recordGame adds a finished (or abandoned) game to the statistics.
+// Games that were never started are ignored.
+func (s *statistics) recordGame(g *Game, won bool) {
+	if !won && !g.Timer.Started() {
+		return
+	}
+
+	elapsed := g.Timer.Elapsed()
+	s.Played++
+	s.TotalTime += elapsed
+	if !won {
+		return
+	}
+
+	s.Won++
+	if s.BestTime == 0 || elapsed < s.BestTime {
+		s.BestTime = elapsed
+	}
+}
+
+// recordGame loads the statistics from preferences, records the game and saves them again.
+// Each game is only recorded once, so it is not counted again when it is closed or replaced after finishing.
+func recordGame(p fyne.Preferences, g *Game, won bool) *statistics {
+	stats := loadStatistics(p)
+	if g.recorded {
+		return stats
+	}
+
+	g.recorded = g.Timer.Started() || won
+	stats.recordGame(g, won)
+	stats.save(p)
+	return stats
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatistics_RecordGame(t *testing.T) {
+	stats := &statistics{}
+	game := newTestGame()
+
+	stats.recordGame(game, false)
+	assert.Equal(t, 0, stats.Played)
+
+	game.Timer = &Timer{elapsed: time.Minute, started: true, stopped: true}
+	stats.recordGame(game, true)
+	game.Timer = &Timer{elapsed: 2 * time.Minute, started: true, stopped: true}
+	stats.recordGame(game, true)
+	game.Timer = &Timer{elapsed: time.Second, started: true, stopped: true}
+	stats.recordGame(game, false)
+
+	assert.Equal(t, 3, stats.Played)
+	assert.Equal(t, 2, stats.Won)
+	assert.Equal(t, time.Minute, stats.BestTime)
+	assert.Equal(t, 3*time.Minute+time.Second, stats.TotalTime)
+}
+
+func TestStatistics_Save(t *testing.T) {
+	prefs := test.NewApp().Preferences()
+	game := newTestGame()
+	game.Timer = &Timer{elapsed: 90 * time.Second, started: true, stopped: true}
+
+	recordGame(prefs, game, true)
+	stats := loadStatistics(prefs)
+	assert.Equal(t, 1, stats.Won)
+	assert.Equal(t, 90*time.Second, stats.BestTime)
+}
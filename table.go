@@ -1,18 +1,26 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math"
-	"sync"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/test"
-	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	solverNodeBudget = 100000
+	winnableAttempts = 50
+	winnableTimeout  = 15 * time.Second
 
-	"github.com/fyne-io/solitaire/faces"
+	// snapTolerance is how far, as a fraction of the card width, a dropped card may be from a pile and still land on it
+	snapTolerance = 0.75
+	snapBackTime  = time.Millisecond * 200
 )
 
 // Table represents the rendering of a game in progress
@@ -21,15 +29,22 @@ type Table struct {
 
 	game     *Game
 	selected *Card
+	daily    string // the date key if playing a daily deal
 
-	float       []*canvas.Image
-	floatSource []*canvas.Image
-	floatPos    fyne.Position
+	background fyne.Resource // an optional image drawn behind the cards
+	leftHanded bool          // mirrors the top row so the stock is on the right
+	smartTap   bool          // a single tap moves a card to its best destination instead of selecting it
+	sound      *soundPlayer  // plays the sound effects, nil for silence
 
-	shuffle *widget.ToolbarAction
+	layout  *tableLayout
+	float   []*canvas.Image
+	drag    *cardHit // where the cards being dragged were picked up from, nil if not dragging
+	targets []PileID // the piles that the dragged cards can be dropped on
+
+	animations   animator
+	winAnimation string // the name of the celebration to play when the game is won
 
-	findCard func(fyne.Position) ([]*Card, []*canvas.Image, bool)
-	stackPos func(int) fyne.Position
+	shuffle *widget.ToolbarAction
 }
 
 // CreateRenderer gets the widget renderer for this table - internal use only
@@ -37,21 +52,6 @@ func (t *Table) CreateRenderer() fyne.WidgetRenderer {
 	return newTableRender(t)
 }
 
-// find card from an image, easier than keeping them in sync
-func (t *Table) cardForPos(pos *canvas.Image) *Card {
-	deck := NewSortedDeck()
-
-	for i, face := range deck.Cards {
-		if face.Face() == pos.Resource {
-			card := NewCard((i%13)+1, Suit(math.Floor(float64(i)/13)))
-			card.FaceUp = true // we know this as we checked the face
-			return card
-		}
-	}
-
-	return nil
-}
-
 func cardEquals(card1, card2 *Card) bool {
 	if card1 == nil || card2 == nil {
 		return card1 == nil && card2 == nil
@@ -60,288 +60,366 @@ func cardEquals(card1, card2 *Card) bool {
 	return card1.Value == card2.Value && card1.Suit == card2.Suit
 }
 
-func (t *Table) cardTapped(cardPos *canvas.Image, pos fyne.Position, move func()) bool {
-	if !withinCardBounds(cardPos, pos) {
-		return false
+// tapCard selects the card that was tapped, or moves the selected card to the pile that was tapped.
+// Tapping the selected card again plays it to a foundation if possible.
+func (t *Table) tapCard(h cardHit) {
+	var card *Card
+	if cards := h.cards(t.game); len(cards) > 0 {
+		card = cards[0]
 	}
-
-	card := t.cardForPos(cardPos)
-	if cardPos.Resource != faces.ForSpace() && (card == nil || !card.FaceUp) {
+	if card != nil && !card.FaceUp {
 		t.selected = nil
 		t.Refresh()
-
-		return true
+		return
 	}
 
 	if t.selected == nil {
+		if t.smartTap && card != nil {
+			if to, ok := t.game.BestMove(card); ok {
+				t.game.MoveCard(card, to)
+				t.Refresh()
+				return
+			}
+		}
 		t.selected = card
+		if card != nil {
+			t.sound.Play(SoundPickUp)
+		}
 	} else {
 		if cardEquals(t.selected, card) {
 			t.game.AutoBuild(card)
 		} else {
-			if move != nil {
-				move()
-			}
+			t.game.MoveCard(t.selected, h.pile)
 		}
 
 		t.selected = nil
 	}
 
 	t.Refresh()
-	return true
 }
 
-func (t *Table) checkStackTapped(render *stackRender, stack *Stack, pos fyne.Position) bool {
-	for i := len(stack.Cards) - 1; i >= 0; i-- {
-		//		card := stack.Cards[i]
+// Refresh updates the table and the toolbar actions that depend on the game state
+func (t *Table) Refresh() {
+	t.refreshShuffle()
+	t.BaseWidget.Refresh()
+}
+
+// loadPreferences applies the preferences that control how this table is shown and played
+func (t *Table) loadPreferences(p fyne.Preferences) {
+	t.game.AutoPlay = p.Bool(prefAutoPlay)
+	t.leftHanded = p.Bool(prefLeftHanded)
+	t.smartTap = p.Bool(prefSmartTap)
+	t.winAnimation = p.String(prefWinAnimation)
+	t.Refresh()
+}
 
-		if t.cardTapped(render.cards[i], pos, func() {
-			t.game.MoveCardToStack(stack, t.selected)
-		}) {
-			return true
-		}
+// SetBackground sets an image to draw behind the cards, or nil to show the plain felt
+func (t *Table) SetBackground(res fyne.Resource) {
+	t.background = res
+	t.Refresh()
+}
+
+// SetLeftHanded moves the stock to the right of the table and the foundations to the left when true
+func (t *Table) SetLeftHanded(left bool) {
+	t.leftHanded = left
+	t.Refresh()
+}
+
+func (t *Table) refreshShuffle() {
+	if t.shuffle == nil {
+		return
 	}
 
-	return t.cardTapped(render.cards[0], pos, func() {
-		t.game.MoveCardToStack(stack, t.selected)
-	})
+	if t.game.CanShuffleStock() {
+		t.shuffle.Enable()
+	} else {
+		t.shuffle.Disable()
+	}
 }
 
+// Restart replaces the current game with a new deal, using the current rule preferences.
+// If the search for a winnable deal is cancelled the current game carries on,
+// but if it runs out of time an unchecked deal is played instead.
 func (t *Table) Restart() {
-	oldWin := t.game.OnWin
-	t.game = NewGame()
-	t.game.OnWin = oldWin
-	t.shuffle.Enable()
+	prefs := fyne.CurrentApp().Preferences()
+	maxPasses := prefs.IntWithFallback(prefPasses, PassesUnlimited)
+	if prefs.Bool(prefWinnable) {
+		seed := time.Now().UnixNano()
+		t.dealWinnable(func(ctx context.Context, progress func(int)) *Game {
+			g, _ := NewWinnableGame(ctx, seed, maxPasses, winnableAttempts, solverNodeBudget, progress)
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return nil
+			}
+			return g
+		}, "", winnableTimeout)
+		return
+	}
+
+	g := NewGame()
+	g.MaxPasses = maxPasses
+	t.setGame(g, "")
+}
+
+// LoadDeal replaces the current game with one set up from a position, such as one parsed by ParseDeal.
+// The stock pass limit is taken from the current rule preferences.
+func (t *Table) LoadDeal(g *Game) {
+	g.MaxPasses = fyne.CurrentApp().Preferences().IntWithFallback(prefPasses, PassesUnlimited)
+	t.setGame(g, "")
+}
+
+// StartDaily replaces the current game with today's daily deal.
+// The search for the deal is not limited by time so that it is the same on every device,
+// and if it is cancelled the current game carries on.
+func (t *Table) StartDaily() {
+	now := time.Now()
+	t.dealWinnable(func(ctx context.Context, progress func(int)) *Game {
+		g, err := NewDailyGame(ctx, now, progress)
+		if err != nil {
+			return nil
+		}
+		return g
+	}, dailyDate(now), 0)
+}
+
+// dealWinnable runs a search for a winnable deal in the background, showing progress over the table.
+// The context passed to deal is cancelled if the user presses cancel, giving context.Canceled,
+// or if the timeout (when not 0) passes, giving context.DeadlineExceeded.
+// If deal returns nil the current game is kept, otherwise it is replaced.
+func (t *Table) dealWinnable(deal func(context.Context, func(int)) *Game, daily string, timeout time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stop := func() {}
+	if timeout > 0 {
+		ctx, stop = context.WithTimeout(ctx, timeout)
+	}
+
+	status := widget.NewLabel("Finding a winnable deal...")
+	content := container.NewVBox(status, widget.NewProgressBarInfinite(), widget.NewButton("Cancel", cancel))
+	pop := widget.NewModalPopUp(content, fyne.CurrentApp().Driver().CanvasForObject(t))
+	pop.Show()
+
+	go func() {
+		defer cancel()
+		defer stop()
+		g := deal(ctx, func(tried int) {
+			fyne.Do(func() {
+				status.SetText(fmt.Sprintf("Finding a winnable deal... %d tried", tried))
+			})
+		})
+
+		fyne.Do(func() {
+			pop.Hide()
+			if g == nil {
+				return
+			}
+			t.setGame(g, daily)
+		})
+	}()
+}
+
+// setGame replaces the current game, recording it as abandoned unless it was already finished
+func (t *Table) setGame(g *Game, daily string) {
+	recordGame(fyne.CurrentApp().Preferences(), t.game, false)
+	t.animations.cancelAll()
+
+	g.OnWin = t.game.OnWin
+	g.OnEvent = t.gameEvent
+	g.AutoPlay = t.game.AutoPlay
+	t.game = g
+	t.daily = daily
+	t.selected = nil
+	t.drag = nil
+	t.targets = nil
 
-	test.WidgetRenderer(t).(*tableRender).game = t.game
 	t.Refresh()
 }
 
 // Dragged is called when the user drags on the table widget
 func (t *Table) Dragged(event *fyne.DragEvent) {
-	t.floatPos = event.Position
-	if !t.float[0].Hidden { // existing drag
-
+	if t.drag != nil && !t.animations.isRunning(animSnapBack) { // existing drag
 		for i := 0; i < len(t.float); i++ {
-			if t.float[i] != nil && !t.float[i].Hidden {
+			if !t.float[i].Hidden {
 				t.float[i].Move(t.float[i].Position().Add(event.Dragged))
 			}
 		}
 		return
 	}
 
+	t.animations.cancel(animSnapBack)
 	if t.selected != nil {
 		return
 	}
 
-	card, source, last := t.findCard(event.Position)
-	if card == nil {
+	h, ok := t.layout.hit(t.game, event.Position)
+	if !ok || h.pile.Type == PileStock {
 		return
 	}
-	if !card[0].FaceUp { // only drag visible cards
+	cards := h.cards(t.game)
+	if len(cards) == 0 || !cards[0].FaceUp { // only drag visible cards
 		return
 	}
 
-	t.selected = card[0]
+	t.drag = &h
+	t.selected = cards[0]
+	t.sound.Play(SoundPickUp)
+	t.targets = t.dropTargets(cards[0])
 
-	for i := 0; i < len(source); i++ {
-		t.floatSource[i] = source[i]
-		t.float[i].Resource = source[i].Resource
-		if last && i == 0 {
-			source[i].Resource = faces.ForSpace()
+	pos := t.layout.cardPos(t.game, h)
+	offsets := t.layout.columnOffsets(t.game.Pile(h.pile))
+	for i, card := range cards {
+		t.float[i].Resource = card.Face()
+		t.float[i].Image = nil
+		t.float[i].Translucency = 0
+		t.float[i].Show()
+		if h.pile.Type == PileTableau {
+			t.layout.placeCard(t.float[i], pos.AddXY(0, offsets[h.index+i]-offsets[h.index]))
 		} else {
-			source[i].Resource = nil
+			t.layout.placeCard(t.float[i], pos)
 		}
-		source[i].Image = nil
-		source[i].Refresh()
+	}
+	t.Refresh()
+}
 
-		t.float[i].Hidden = false
-		t.float[i].Image = nil
-		t.float[i].Refresh()
-		updateCardPosition(t.float[i], source[i].Position().X, source[i].Position().Y)
+// dragged returns true if the card at index in a pile has been lifted to be dragged
+func (t *Table) dragged(id PileID, index int) bool {
+	return t.drag != nil && t.drag.pile == id && index >= t.drag.index && index >= 0
+}
+
+// draggedFrom returns the index in the pile of the first card being dragged, or -1 if none are dragged from it
+func (t *Table) draggedFrom(id PileID) int {
+	if t.drag == nil || t.drag.pile != id {
+		return -1
 	}
+	return t.drag.index
 }
 
-// DragEnd is called when the user stops dragging on the table widget
+// DragEnd is called when the user stops dragging on the table widget.
+// The cards land on the closest valid pile, if one is near enough, otherwise they slide back.
 func (t *Table) DragEnd() {
-	for i := 0; i < ValueKing; i++ {
-		t.float[i].Hide()
+	if t.drag == nil || t.animations.isRunning(animSnapBack) {
+		return
 	}
 
-	if t.dropCard(t.floatPos) {
+	target, ok := t.snapTarget(t.float[0].Position())
+	t.targets = nil
+	if !ok {
+		t.sound.Play(SoundIllegal)
+		t.animateSnapBack()
 		return
 	}
 
-	for i := 0; i < ValueKing; i++ {
-		if t.floatSource[i] != nil {
-			t.floatSource[i].Resource = t.float[i].Resource
-			t.floatSource[i].Refresh()
-		}
-	}
+	t.hideFloats()
+	t.drag = nil
+	t.game.MoveCard(t.selected, target)
+	t.selected = nil
+	t.Refresh()
 }
 
-// Tapped is called when the user taps the table widget
-func (t *Table) Tapped(event *fyne.PointEvent) {
-	render := test.WidgetRenderer(t).(*tableRender)
-
-	if withinCardBounds(render.deck, event.Position) {
-		t.selected = nil
-		t.game.DrawThree()
+// gameEvent gives feedback for the things that happen in the game
+func (t *Table) gameEvent(e GameEvent) {
+	t.sound.PlayEvent(e)
+}
 
-		if len(t.game.Drawn.Cards) == 0 {
-			t.shuffle.Enable()
-		} else {
-			t.shuffle.Disable()
+// dropTargets returns the piles that the card could be moved to
+func (t *Table) dropTargets(card *Card) []PileID {
+	var targets []PileID
+	for _, id := range dropPiles() {
+		if t.game.CanMoveCard(card, id) {
+			targets = append(targets, id)
 		}
-
-		render.Refresh()
-		return
 	}
-
-	t.dropCard(event.Position)
+	return targets
 }
 
-func (t *Table) dropCard(pos fyne.Position) bool {
-	render := test.WidgetRenderer(t).(*tableRender)
-
-	if t.game.Draw3 != nil {
-		if t.cardTapped(render.pile3, pos, nil) {
-			return true
-		}
-	} else if t.game.Draw2 != nil {
-		if t.cardTapped(render.pile2, pos, nil) {
-			return true
-		}
-	} else if t.game.Draw1 != nil {
-		if t.cardTapped(render.pile1, pos, nil) {
-			return true
+// snapTarget returns the drop target closest to a card dropped at pos, if it is within the snap tolerance
+func (t *Table) snapTarget(pos fyne.Position) (PileID, bool) {
+	var best PileID
+	found := false
+	bestDist := t.layout.cardSize.Width * snapTolerance
+	for _, id := range t.targets {
+		p := t.layout.pilePos(t.game, id)
+		dx, dy := float64(pos.X-p.X), float64(pos.Y-p.Y)
+		if dist := float32(math.Sqrt(dx*dx + dy*dy)); dist <= bestDist {
+			best, bestDist, found = id, dist, true
 		}
 	}
+	return best, found
+}
 
-	if t.cardTapped(render.build1, pos, func() {
-		t.game.MoveCardToBuild(t.game.Build1, t.selected)
-	}) {
-		return true
-	} else if t.cardTapped(render.build2, pos, func() {
-		t.game.MoveCardToBuild(t.game.Build2, t.selected)
-	}) {
-		return true
-	} else if t.cardTapped(render.build3, pos, func() {
-		t.game.MoveCardToBuild(t.game.Build3, t.selected)
-	}) {
-		return true
-	} else if t.cardTapped(render.build4, pos, func() {
-		t.game.MoveCardToBuild(t.game.Build4, t.selected)
-	}) {
-		return true
+// animateSnapBack slides the dragged cards back to where they were picked up from
+func (t *Table) animateSnapBack() {
+	var starts []fyne.Position
+	for i := 0; i < len(t.float) && !t.float[i].Hidden; i++ {
+		starts = append(starts, t.float[i].Position())
 	}
+	end := t.layout.cardPos(t.game, *t.drag)
+	delta := end.Subtract(starts[0])
 
-	if t.checkStackTapped(render.stack1, t.game.Stack1, pos) {
-		return true
-	} else if t.checkStackTapped(render.stack2, t.game.Stack2, pos) {
-		return true
-	} else if t.checkStackTapped(render.stack3, t.game.Stack3, pos) {
-		return true
-	} else if t.checkStackTapped(render.stack4, t.game.Stack4, pos) {
-		return true
-	} else if t.checkStackTapped(render.stack5, t.game.Stack5, pos) {
-		return true
-	} else if t.checkStackTapped(render.stack6, t.game.Stack6, pos) {
-		return true
-	} else if t.checkStackTapped(render.stack7, t.game.Stack7, pos) {
-		return true
-	}
+	t.animations.start(animSnapBack, snapBackTime, fyne.AnimationEaseOut, func(done float32) {
+		for i, start := range starts {
+			t.float[i].Move(start.AddXY(delta.X*done, delta.Y*done))
+		}
+	}, func(bool) {
+		t.endSnapBack()
+	})
+}
 
-	t.selected = nil // clicked elsewhere
+// endSnapBack hides the dragged cards and shows the originals again
+func (t *Table) endSnapBack() {
+	t.hideFloats()
+	t.drag = nil
+	t.selected = nil
 	t.Refresh()
-	return false
 }
 
-func (t *Table) finishAnimation() {
-	anim := container.NewWithoutLayout()
-	anim.Resize(t.Size())
-	fyne.CurrentApp().Driver().CanvasForObject(t).Overlays().Add(anim)
-	wg := &sync.WaitGroup{}
-
-	for _, p := range []*Stack{t.game.Build1, t.game.Build2, t.game.Build3, t.game.Build4} {
-		c := len(p.Cards)
-		if c == 0 {
-			continue
-		}
-		wg.Add(c)
+// hideFloats hides the images used to draw the cards being dragged
+func (t *Table) hideFloats() {
+	for _, f := range t.float {
+		f.Hide()
 	}
-	go func() {
-		for i := ValueKing; i > 0; i-- {
-			for j, p := range []*Stack{t.game.Build1, t.game.Build2, t.game.Build3, t.game.Build4} {
-				card := p.Pop()
-				if card == nil {
-					break
-				}
-				pos := t.stackPos(j)
-
-				off := fyne.Delta{DX: -2, DY: 1}
-				switch j {
-				case 0:
-					off.DX = 2
-				case 1:
-					off.DX = 2
-					off.DY = -1
-				case 2:
-					off.DY = -1
-				}
-				fyne.Do(func() {
-					image := t.startCardAnimation(card, pos, off, wg)
-					anim.Objects = append([]fyne.CanvasObject{image}, anim.Objects...)
-
-					t.Refresh()
-				})
-				time.Sleep(time.Second / 6)
-			}
-		}
-
-		wg.Wait()
-		anim.Objects = []fyne.CanvasObject{}
-		fyne.CurrentApp().Driver().CanvasForObject(t).Overlays().Remove(anim)
-	}()
 }
 
-func (t *Table) startCardAnimation(card *Card, pos fyne.Position, off fyne.Delta, wg *sync.WaitGroup) fyne.CanvasObject {
-	bounds := t.Size()
-	pad := theme.Padding()
-	i := canvas.NewImageFromResource(faces.ForCard(card.Value, int(card.Suit)))
-	i.Resize(cardSize)
-	i.Move(pos)
-
-	go func() {
-		for pos.X > -cardSize.Width-pad && pos.Y > -cardSize.Height-pad && pos.X < bounds.Width && pos.Y < bounds.Height {
-			pos = pos.Add(off)
-			fyne.Do(func() {
-				i.Move(pos)
-			})
+// dropPiles returns the identifiers of every pile that cards can be dropped on
+func dropPiles() []PileID {
+	ids := make([]PileID, 0, FoundationCount+TableauCount)
+	for i := 0; i < FoundationCount; i++ {
+		ids = append(ids, FoundationPile(i))
+	}
+	for i := 0; i < TableauCount; i++ {
+		ids = append(ids, TableauPile(i))
+	}
+	return ids
+}
 
-			time.Sleep(time.Millisecond * 12)
-		}
+// Tapped is called when the user taps the table widget
+func (t *Table) Tapped(event *fyne.PointEvent) {
+	h, ok := t.layout.hit(t.game, event.Position)
+	if !ok {
+		t.selected = nil // clicked elsewhere
+		t.Refresh()
+		return
+	}
 
-		fyne.Do(func() {
-			i.Hide()
-			wg.Done()
-		})
-	}()
+	if h.pile.Type == PileStock {
+		t.selected = nil
+		t.game.DrawThree()
 
-	return i
+		t.Refresh()
+		return
+	}
+	t.tapCard(h)
 }
 
 // NewTable creates a new table widget for the specified game
 func NewTable(g *Game) *Table {
-	table := &Table{game: g}
+	table := &Table{game: g, layout: newTableLayout()}
 	table.ExtendBaseWidget(table)
+	g.OnEvent = table.gameEvent
 
-	table.float = make([]*canvas.Image, ValueKing)
-	for i := 0; i < ValueKing; i++ {
+	table.float = make([]*canvas.Image, maxStackCards) // enough to drag every card of a column
+	for i := range table.float {
 		table.float[i] = &canvas.Image{}
 		table.float[i].Hide()
 	}
-	table.floatSource = make([]*canvas.Image, ValueKing)
 
 	return table
 }
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// newDragTable returns a laid out table where the two of hearts, at the top of column 1,
+// can move onto the black three at the top of column 0
+func newDragTable() (*Table, *tableRender) {
+	g := newEmptyGame()
+	g.Tableau[0].Cards = []*Card{{Value: 3, Suit: SuitClubs, FaceUp: true}}
+	g.Tableau[1].Cards = []*Card{{Value: 9, Suit: SuitSpades}, {Value: 2, Suit: SuitHearts, FaceUp: true}}
+
+	table := NewTable(g)
+	table.Resize(fyne.NewSize(700, 500))
+	render := test.WidgetRenderer(table).(*tableRender)
+	render.Layout(table.Size())
+	table.Refresh()
+	return table, render
+}
+
+func TestTable_DragTargets(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	table, render := newDragTable()
+
+	start := render.stacks[1].cards[1].Position().Add(fyne.NewPos(5, 5))
+	table.Dragged(&fyne.DragEvent{PointEvent: fyne.PointEvent{Position: start}})
+	assert.Equal(t, []PileID{TableauPile(0)}, table.targets)
+	assert.True(t, render.highlights[TableauPile(0)].Visible())
+	assert.False(t, render.highlights[TableauPile(2)].Visible())
+}
+
+func TestTable_DragSnapsToTarget(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	table, render := newDragTable()
+
+	start := render.stacks[1].cards[1].Position().Add(fyne.NewPos(5, 5))
+	table.Dragged(&fyne.DragEvent{PointEvent: fyne.PointEvent{Position: start}})
+
+	// close to, but not over, the target pile
+	target := render.stacks[0].cards[0].Position()
+	delta := target.Subtract(render.stacks[1].cards[1].Position()).Add(fyne.NewPos(table.layout.cardSize.Width/3, 10))
+	table.Dragged(&fyne.DragEvent{PointEvent: fyne.PointEvent{Position: start.Add(delta)},
+		Dragged: fyne.NewDelta(delta.X, delta.Y)})
+	table.DragEnd()
+
+	assert.Equal(t, 2, len(table.game.Tableau[0].Cards))
+	assert.Equal(t, 1, len(table.game.Tableau[1].Cards))
+	assert.Nil(t, table.targets)
+	assert.False(t, render.highlights[TableauPile(0)].Visible())
+}
+
+func TestTable_DragSnapsBack(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	table, render := newDragTable()
+
+	start := render.stacks[1].cards[1].Position().Add(fyne.NewPos(5, 5))
+	table.Dragged(&fyne.DragEvent{PointEvent: fyne.PointEvent{Position: start}})
+	table.Dragged(&fyne.DragEvent{PointEvent: fyne.PointEvent{Position: start.Add(fyne.NewPos(300, 0))},
+		Dragged: fyne.NewDelta(300, 0)})
+	table.DragEnd()
+
+	assert.Equal(t, 2, len(table.game.Tableau[1].Cards))
+	assert.Eventually(t, func() bool {
+		return table.float[0].Hidden
+	}, time.Second, time.Millisecond*10)
+	assert.False(t, table.animations.isRunning(animSnapBack))
+	assert.Nil(t, table.selected)
+	assert.NotNil(t, render.stacks[1].cards[1].Resource)
+}
+
+func TestTable_DragFromFoundation(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	table, render := newDragTable()
+	ace := &Card{Value: 1, Suit: SuitDiamonds, FaceUp: true}
+	two := &Card{Value: 2, Suit: SuitDiamonds, FaceUp: true}
+	table.game.Foundations[2].Cards = []*Card{ace, two}
+	table.Refresh()
+
+	start := render.builds[2].Position().Add(fyne.NewPos(5, 5))
+	table.Dragged(&fyne.DragEvent{PointEvent: fyne.PointEvent{Position: start}})
+	assert.Equal(t, two, table.selected)
+	assert.Equal(t, []PileID{TableauPile(0)}, table.targets)
+	assert.Equal(t, ace.Face(), render.builds[2].Resource)
+
+	delta := render.stacks[0].cards[0].Position().Subtract(render.builds[2].Position()).Add(fyne.NewPos(0, 20))
+	table.Dragged(&fyne.DragEvent{PointEvent: fyne.PointEvent{Position: start.Add(delta)},
+		Dragged: fyne.NewDelta(delta.X, delta.Y)})
+	table.DragEnd()
+	assert.Equal(t, []*Card{ace}, table.game.Foundations[2].Cards)
+	assert.Equal(t, two, table.game.Tableau[0].Top())
+}
+
+func TestTable_TapFromFoundation(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	table, render := newDragTable()
+	ace := &Card{Value: 1, Suit: SuitDiamonds, FaceUp: true}
+	two := &Card{Value: 2, Suit: SuitDiamonds, FaceUp: true}
+	table.game.Foundations[2].Cards = []*Card{ace, two}
+	table.Refresh()
+
+	table.Tapped(&fyne.PointEvent{Position: render.builds[2].Position().Add(fyne.NewPos(5, 5))})
+	assert.True(t, cardEquals(two, table.selected))
+	table.Tapped(&fyne.PointEvent{Position: render.stacks[0].cards[0].Position().Add(fyne.NewPos(5, 5))})
+	assert.Equal(t, 1, len(table.game.Foundations[2].Cards))
+	assert.Equal(t, 2, len(table.game.Tableau[0].Cards))
+}
+
+func TestTable_SmartTap(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	table, render := newDragTable()
+	table.smartTap = true
+
+	table.Tapped(&fyne.PointEvent{Position: render.stacks[1].cards[1].Position().Add(fyne.NewPos(5, 5))})
+	assert.Nil(t, table.selected)
+	assert.Equal(t, 2, len(table.game.Tableau[0].Cards))
+	assert.Equal(t, 1, len(table.game.Tableau[1].Cards))
+}
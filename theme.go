@@ -5,23 +5,91 @@ import (
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/theme"
+
+	"github.com/fyne-io/solitaire/faces"
+)
+
+const (
+	prefFelt       = "table.felt"
+	prefVariant    = "table.variant"
+	prefBackground = "table.background"
+
+	variantDark   = "Dark"
+	variantLight  = "Light"
+	variantSystem = "System"
+
+	customFeltName = "Custom"
+
+	// colorNameFelt is the colour of the table behind the cards
+	colorNameFelt fyne.ThemeColorName = "felt"
+	// colorNameFeltLine is the line between the top row of the table and the tableau
+	colorNameFeltLine fyne.ThemeColorName = "feltLine"
 )
 
+var (
+	variantNames = []string{variantDark, variantLight, variantSystem}
+
+	feltNames  = []string{"Green", "Blue", "Red", "Grey", "Purple"}
+	feltColors = map[string]color.NRGBA{
+		"Green":  {R: 0x07, G: 0x63, B: 0x24, A: 0xff},
+		"Blue":   {R: 0x0d, G: 0x3b, B: 0x6e, A: 0xff},
+		"Red":    {R: 0x7a, G: 0x14, B: 0x1b, A: 0xff},
+		"Grey":   {R: 0x44, G: 0x48, B: 0x4c, A: 0xff},
+		"Purple": {R: 0x46, G: 0x23, B: 0x66, A: 0xff},
+	}
+)
+
+// gameTheme provides the colour of the table felt, which is only drawn behind the cards,
+// so the toolbar and dialogs keep the colours of their variant.
+// The variant is forced to light or dark unless it is set to follow the system.
 type gameTheme struct {
 	fyne.Theme
+
+	felt    color.NRGBA
+	variant string
 }
 
-func newGameTheme() fyne.Theme {
-	return &gameTheme{theme.DefaultTheme()}
+func newGameTheme(felt color.NRGBA, variant string) fyne.Theme {
+	return &gameTheme{Theme: theme.DefaultTheme(), felt: felt, variant: variant}
 }
 
-func (g *gameTheme) Color(n fyne.ThemeColorName, _ fyne.ThemeVariant) color.Color {
+// loadTheme returns the theme for the felt and variant chosen in the preferences
+func loadTheme(p fyne.Preferences) fyne.Theme {
+	return newGameTheme(feltForPreference(p.String(prefFelt)), p.StringWithFallback(prefVariant, variantDark))
+}
+
+func (g *gameTheme) Color(n fyne.ThemeColorName, v fyne.ThemeVariant) color.Color {
 	switch n {
-	case theme.ColorNameBackground:
-		return color.RGBA{R: 0x07, G: 0x63, B: 0x24, A: 0xff}
-	case theme.ColorNameSeparator:
-		return color.RGBA{R: 0x02, G: 0x52, B: 0x10, A: 0xff}
+	case colorNameFelt:
+		return g.felt
+	case colorNameFeltLine:
+		return darken(g.felt, 0.25)
+	}
+
+	switch g.variant {
+	case variantLight:
+		v = theme.VariantLight
+	case variantSystem:
+	default:
+		v = theme.VariantDark
+	}
+	return g.Theme.Color(n, v)
+}
+
+// feltForPreference returns the felt colour for a stored preset name or "#rrggbb" value
+func feltForPreference(value string) color.NRGBA {
+	if c, ok := feltColors[value]; ok {
+		return c
+	}
+	if c, err := faces.ParseHexColor(value); err == nil {
+		return c
 	}
+	return feltColors[feltNames[0]]
+}
 
-	return g.Theme.Color(n, theme.VariantDark)
+func darken(c color.NRGBA, amount float64) color.NRGBA {
+	scale := func(v uint8) uint8 {
+		return uint8(float64(v) * (1 - amount))
+	}
+	return color.NRGBA{R: scale(c.R), G: scale(c.G), B: scale(c.B), A: c.A}
 }
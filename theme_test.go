@@ -0,0 +1,47 @@
+package main
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeltForPreference(t *testing.T) {
+	assert.Equal(t, feltColors["Green"], feltForPreference(""))
+	assert.Equal(t, feltColors["Blue"], feltForPreference("Blue"))
+	assert.Equal(t, color.NRGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xff}, feltForPreference("#123456"))
+	assert.Equal(t, feltColors["Green"], feltForPreference("not a colour"))
+}
+
+func TestGameTheme_Variant(t *testing.T) {
+	felt := feltColors["Red"]
+	dark := newGameTheme(felt, variantDark)
+	light := newGameTheme(felt, variantLight)
+	system := newGameTheme(felt, variantSystem)
+
+	assert.Equal(t, felt, dark.Color(colorNameFelt, theme.VariantLight))
+	assert.Equal(t, felt, light.Color(colorNameFelt, theme.VariantDark))
+
+	def := theme.DefaultTheme()
+	assert.Equal(t, def.Color(theme.ColorNameBackground, theme.VariantLight),
+		light.Color(theme.ColorNameBackground, theme.VariantDark))
+	assert.Equal(t, def.Color(theme.ColorNameForeground, theme.VariantDark),
+		dark.Color(theme.ColorNameForeground, theme.VariantLight))
+	assert.Equal(t, def.Color(theme.ColorNameForeground, theme.VariantLight),
+		light.Color(theme.ColorNameForeground, theme.VariantDark))
+	assert.Equal(t, def.Color(theme.ColorNameForeground, theme.VariantLight),
+		system.Color(theme.ColorNameForeground, theme.VariantLight))
+}
+
+func TestLoadTheme(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+
+	a.Preferences().SetString(prefFelt, "Purple")
+	a.Preferences().SetString(prefVariant, variantLight)
+	th := loadTheme(a.Preferences())
+	assert.Equal(t, feltColors["Purple"], th.Color(colorNameFelt, theme.VariantDark))
+}
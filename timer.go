@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Timer measures how long a game has been played, not counting any time that it was paused.
+// The zero value is a timer that has not started.
+type Timer struct {
+	elapsed time.Duration
+	resumed time.Time
+
+	started, paused, stopped bool
+
+	now func() time.Time
+}
+
+// Start begins timing, if the timer has not already been started
+func (t *Timer) Start() {
+	if t.started {
+		return
+	}
+
+	t.started = true
+	t.resumed = t.clock()
+}
+
+// Pause temporarily stops the timer, for example when the app is in the background
+func (t *Timer) Pause() {
+	if !t.Running() {
+		t.paused = true
+		return
+	}
+
+	t.elapsed += t.clock().Sub(t.resumed)
+	t.paused = true
+}
+
+// Resume continues a timer that was paused
+func (t *Timer) Resume() {
+	if !t.paused {
+		return
+	}
+
+	t.paused = false
+	t.resumed = t.clock()
+}
+
+// Stop ends timing permanently, the elapsed time will no longer change
+func (t *Timer) Stop() {
+	if t.Running() {
+		t.elapsed += t.clock().Sub(t.resumed)
+	}
+	t.stopped = true
+}
+
+// Started returns true if the timer has been started, even if it is now paused or stopped
+func (t *Timer) Started() bool {
+	return t.started
+}
+
+// Running returns true if the timer is currently counting
+func (t *Timer) Running() bool {
+	return t.started && !t.paused && !t.stopped
+}
+
+// Elapsed returns the total time counted by this timer
+func (t *Timer) Elapsed() time.Duration {
+	if t.Running() {
+		return t.elapsed + t.clock().Sub(t.resumed)
+	}
+
+	return t.elapsed
+}
+
+func (t *Timer) clock() time.Time {
+	if t.now == nil {
+		return time.Now()
+	}
+
+	return t.now()
+}
+
+// formatDuration returns a minutes and seconds representation of the duration, like "3:07"
+func formatDuration(d time.Duration) string {
+	secs := int(d / time.Second)
+	return fmt.Sprintf("%d:%02d", secs/60, secs%60)
+}
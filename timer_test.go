@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestTimer() (*Timer, *testClock) {
+	clock := &testClock{now: time.Unix(0, 0)}
+	return &Timer{now: func() time.Time { return clock.now }}, clock
+}
+
+func TestTimer_Start(t *testing.T) {
+	timer, clock := newTestTimer()
+	clock.advance(time.Second)
+	assert.Equal(t, time.Duration(0), timer.Elapsed())
+	assert.False(t, timer.Started())
+
+	timer.Start()
+	clock.advance(time.Second)
+	timer.Start() // already running, no reset
+	clock.advance(time.Second)
+	assert.Equal(t, 2*time.Second, timer.Elapsed())
+	assert.True(t, timer.Running())
+}
+
+func TestTimer_Pause(t *testing.T) {
+	timer, clock := newTestTimer()
+	timer.Start()
+	clock.advance(time.Second)
+
+	timer.Pause()
+	clock.advance(time.Minute)
+	assert.Equal(t, time.Second, timer.Elapsed())
+	assert.False(t, timer.Running())
+
+	timer.Resume()
+	clock.advance(time.Second)
+	assert.Equal(t, 2*time.Second, timer.Elapsed())
+}
+
+func TestTimer_PauseBeforeStart(t *testing.T) {
+	timer, clock := newTestTimer()
+	timer.Pause()
+	timer.Start()
+	clock.advance(time.Second)
+	assert.Equal(t, time.Duration(0), timer.Elapsed())
+
+	timer.Resume()
+	clock.advance(time.Second)
+	assert.Equal(t, time.Second, timer.Elapsed())
+}
+
+func TestTimer_Stop(t *testing.T) {
+	timer, clock := newTestTimer()
+	timer.Start()
+	clock.advance(time.Second)
+	timer.Stop()
+	clock.advance(time.Second)
+	timer.Resume()
+	assert.Equal(t, time.Second, timer.Elapsed())
+	assert.False(t, timer.Running())
+}
+
+func TestGame_TimerStartsOnFirstMove(t *testing.T) {
+	game := newTestGame()
+	assert.False(t, game.Timer.Started())
+
+	game.DrawThree()
+	assert.True(t, game.Timer.Running())
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "0:00", formatDuration(0))
+	assert.Equal(t, "3:07", formatDuration(3*time.Minute+7*time.Second+400*time.Millisecond))
+	assert.Equal(t, "75:00", formatDuration(75*time.Minute))
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Validate checks that the game is in a position that could be reached by playing, returning an error if not.
+// Every card must be on the table exactly once, the stock face down and the waste face up,
+// foundations must be built up in suit from the ace, and the face down cards of each tableau column
+// must be below its face up cards, which are built down in alternating colours.
+func (g *Game) Validate() error {
+	if g.Hand == nil || g.Drawn == nil {
+		return fmt.Errorf("the stock and waste must be set")
+	}
+	if len(g.Foundations) != FoundationCount || len(g.Tableau) != TableauCount {
+		return fmt.Errorf("expected %d foundations and %d columns, found %d and %d",
+			FoundationCount, TableauCount, len(g.Foundations), len(g.Tableau))
+	}
+
+	found := make(map[string]int)
+	count := func(name string, cards []*Card) error {
+		for _, c := range cards {
+			if c == nil || !c.valid() {
+				return fmt.Errorf("%s has an invalid card %v", name, c)
+			}
+			found[c.Notation()]++
+			if found[c.Notation()] > 1 {
+				return fmt.Errorf("%s has card %s, which appears more than once", name, c)
+			}
+		}
+		return nil
+	}
+
+	if err := count(dealStock, g.Hand.Cards); err != nil {
+		return err
+	}
+	for _, c := range g.Hand.Cards {
+		if c.FaceUp {
+			return fmt.Errorf("%s has face up card %s", dealStock, c)
+		}
+	}
+	if err := count(dealWaste, g.Drawn.Cards); err != nil {
+		return err
+	}
+	if err := g.validateWaste(); err != nil {
+		return err
+	}
+
+	for i, f := range g.Foundations {
+		name := fmt.Sprintf("%s %d", dealFoundation, i+1)
+		if err := count(name, f.Cards); err != nil {
+			return err
+		}
+		for v, c := range f.Cards {
+			if c.Value != v+1 || c.Suit != f.Cards[0].Suit || !c.FaceUp {
+				return fmt.Errorf("%s must be built up in suit from the ace, found %s", name, c)
+			}
+		}
+	}
+	for i, s := range g.Tableau {
+		name := fmt.Sprintf("%s %d", dealTableau, i+1)
+		if err := count(name, s.Cards); err != nil {
+			return err
+		}
+		if err := checkColumn(name, s.Cards); err != nil {
+			return err
+		}
+	}
+
+	if missing := missingCards(found); len(missing) > 0 {
+		return fmt.Errorf("%d cards are missing: %s", len(missing), strings.Join(missing, " "))
+	}
+	return nil
+}
+
+// validateWaste checks that the waste cards are face up and that Draw1 to Draw3 show the top of the waste
+func (g *Game) validateWaste() error {
+	for _, c := range g.Drawn.Cards {
+		if !c.FaceUp {
+			return fmt.Errorf("%s has face down card %s", dealWaste, c)
+		}
+	}
+
+	shown := g.Drawn.Cards
+	if len(shown) > 3 {
+		shown = shown[len(shown)-3:]
+	}
+	for i, slot := range []*Card{g.Draw1, g.Draw2, g.Draw3} {
+		var want *Card
+		if i < len(shown) {
+			want = shown[i]
+		}
+		if slot != want {
+			return fmt.Errorf("waste slot %d shows %v, expected %v", i+1, slot, want)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGame_Validate(t *testing.T) {
+	assert.NoError(t, newTestGame().Validate())
+	g, err := ParseDeal(testDeal)
+	if assert.NoError(t, err) {
+		assert.NoError(t, g.Validate())
+	}
+
+	for name, tc := range map[string]struct {
+		change func(g *Game)
+		err    string
+	}{
+		"duplicate": {func(g *Game) {
+			g.Hand.Cards[0] = &Card{Value: g.Hand.Cards[1].Value, Suit: g.Hand.Cards[1].Suit}
+		}, "appears more than once"},
+		"missing":         {func(g *Game) { g.Hand.Cards = g.Hand.Cards[1:] }, "1 cards are missing"},
+		"invalid":         {func(g *Game) { g.Hand.Cards[0] = &Card{Value: 14} }, "Stock has an invalid card"},
+		"face up stock":   {func(g *Game) { g.Hand.Cards[3].FaceUp = true }, "Stock has face up card"},
+		"face down waste": {func(g *Game) { g.DrawThree(); g.Drawn.Cards[0].FaceUp = false }, "Waste has face down card"},
+		"stale waste":     {func(g *Game) { g.Drawn.Push(g.Hand.Pop()); g.WasteTop().TurnFaceUp() }, "waste slot 1"},
+		"foundation gap": {func(g *Game) {
+			g.Foundations[0].Push(g.Tableau[0].Pop())
+		}, "Foundation 1 must be built up in suit from the ace"},
+		"hidden top":      {func(g *Game) { g.Tableau[3].Top().FaceUp = false }, "Tableau 4 must have a face up card on top"},
+		"face down above": {func(g *Game) { g.Tableau[2].Cards[0].FaceUp = true }, "Tableau 3 has face down card"},
+		"broken run":      {func(g *Game) { g.Tableau[1].Push(g.Tableau[0].Pop()) }, "Tableau 2 has"},
+		"no columns":      {func(g *Game) { g.Tableau = g.Tableau[1:] }, "expected 4 foundations and 7 columns"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := newTestGame()
+			tc.change(g)
+			err := g.Validate()
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+func TestGame_MoveCardToStack_FaceDownRun(t *testing.T) {
+	g := newEmptyGame()
+	g.Tableau[0].Cards = []*Card{NewCard(9, SuitClubs), NewCard(7, SuitSpades), {Value: 6, Suit: SuitHearts, FaceUp: true}}
+	g.Tableau[1].Cards = []*Card{{Value: 8, Suit: SuitHearts, FaceUp: true}}
+
+	g.MoveCardToStack(g.Tableau[1], g.Tableau[0].Cards[1])
+	assert.Equal(t, 3, len(g.Tableau[0].Cards))
+	assert.Equal(t, 1, len(g.Tableau[1].Cards))
+}
+
+func TestGame_MoveCardToStack_EventAfterMove(t *testing.T) {
+	g := newEmptyGame()
+	g.Tableau[0].Cards = []*Card{NewCard(9, SuitClubs), {Value: 7, Suit: SuitSpades, FaceUp: true}, {Value: 6, Suit: SuitHearts, FaceUp: true}}
+	g.Tableau[1].Cards = []*Card{{Value: 8, Suit: SuitHearts, FaceUp: true}}
+
+	var moved []int
+	g.OnEvent = func(e GameEvent) {
+		if e == EventMove {
+			moved = append(moved, len(g.Tableau[0].Cards), len(g.Tableau[1].Cards))
+		}
+	}
+	g.MoveCardToStack(g.Tableau[1], g.Tableau[0].Cards[1])
+	assert.Equal(t, []int{1, 3}, moved)
+	assert.True(t, g.Tableau[0].Top().FaceUp)
+}
+
+func TestGame_MoveCardToBuild_CopyOfCard(t *testing.T) {
+	g := newEmptyGame()
+	ace := &Card{Value: 1, Suit: SuitDiamonds, FaceUp: true}
+	g.Tableau[0].Cards = []*Card{ace}
+
+	g.MoveCardToBuild(g.Foundations[0], NewCard(1, SuitDiamonds))
+	assert.Same(t, ace, g.Foundations[0].Top())
+	assert.True(t, g.Foundations[0].Top().FaceUp)
+	assert.Empty(t, g.Tableau[0].Cards)
+
+	g.MoveCardToBuild(g.Foundations[1], NewCard(1, SuitSpades)) // not on the table
+	assert.Empty(t, g.Foundations[1].Cards)
+}
+
+// tableCards returns every card in the game, in pile order, so that moves can choose between them
+func tableCards(g *Game) []*Card {
+	cards := append([]*Card{}, g.Hand.Cards...)
+	cards = append(cards, g.Drawn.Cards...)
+	for _, f := range g.Foundations {
+		cards = append(cards, f.Cards...)
+	}
+	for _, s := range g.Tableau {
+		cards = append(cards, s.Cards...)
+	}
+	return cards
+}
+
+// playMoves applies moves described by pairs of bytes to the game, checking it is still valid after each.
+// The first byte picks the kind of move and its destination, the second picks the card.
+// Most moves chosen this way are illegal, which the game should ignore.
+func playMoves(g *Game, moves []byte) error {
+	for i := 0; i+1 < len(moves); i += 2 {
+		kind, dest := moves[i]%6, int(moves[i]/6)
+		cards := tableCards(g)
+		card := cards[int(moves[i+1])%len(cards)]
+
+		switch kind {
+		case 0:
+			g.DrawThree()
+		case 1:
+			if to, ok := g.BestMove(card); ok {
+				g.MoveCard(card, to)
+			}
+		case 2:
+			g.MoveCard(card, TableauPile(dest%TableauCount))
+		case 3:
+			g.MoveCardToStack(g.Tableau[dest%TableauCount], card)
+		case 4:
+			g.MoveCardToBuild(g.Foundations[dest%FoundationCount], card)
+		case 5:
+			// a card equal to one in the game, which may be face down, rather than the card itself
+			copied := &Card{Value: card.Value, Suit: card.Suit}
+			if dest%2 == 0 {
+				g.MoveCardToStack(g.Tableau[dest%TableauCount], copied)
+			} else {
+				g.MoveCardToBuild(g.Foundations[dest%FoundationCount], copied)
+			}
+		}
+
+		if err := g.Validate(); err != nil {
+			return fmt.Errorf("move %d (%d, %d): %w", i/2, moves[i], moves[i+1], err)
+		}
+	}
+	return nil
+}
+
+func TestGame_RandomMoves(t *testing.T) {
+	for seed := int64(1); seed <= 100; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		moves := make([]byte, 600)
+		r.Read(moves)
+
+		g := NewGameFromSeed(seed)
+		g.AutoPlay = seed%2 == 0
+		g.MaxPasses = int(seed % 4)
+		if !assert.NoError(t, playMoves(g, moves), "seed %d", seed) {
+			return
+		}
+	}
+}
+
+func FuzzGame_Moves(f *testing.F) {
+	f.Add(int64(0xace), false, []byte{0, 0, 1, 30, 2, 40, 3, 12, 4, 51, 5, 7})
+	f.Add(int64(1), true, []byte{1, 0, 1, 1, 1, 2, 1, 3, 1, 4, 1, 5, 0, 0, 1, 6})
+	f.Add(int64(42), false, []byte{9, 28, 15, 46, 21, 50, 27, 33, 33, 35})
+
+	f.Fuzz(func(t *testing.T, seed int64, autoPlay bool, moves []byte) {
+		g := NewGameFromSeed(seed)
+		g.AutoPlay = autoPlay
+		if err := playMoves(g, moves); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
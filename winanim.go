@@ -0,0 +1,430 @@
+package main
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/fyne-io/solitaire/faces"
+)
+
+const (
+	prefWinAnimation = "win.animation"
+
+	// maxCelebration is the longest that a win animation can run for
+	maxCelebration = time.Second * 20
+	// maxTrails limits the number of images left behind by the cascade
+	maxTrails = 600
+)
+
+// winAnimation is a celebration drawn when a game is won
+type winAnimation interface {
+	// step advances the animation to the time since it started, returning false once it has finished
+	step(elapsed time.Duration) bool
+}
+
+// winAnimationStyle is a named way of creating a win animation for a celebration
+type winAnimationStyle struct {
+	name  string
+	start func(*celebration) winAnimation
+}
+
+var winAnimationStyles = []winAnimationStyle{
+	{name: "Cascade", start: newCascade},
+	{name: "Fireworks", start: newFireworks},
+	{name: "Fan Out", start: newFanOut},
+}
+
+// winAnimationNames returns the names of the win animations, for showing in the settings
+func winAnimationNames() []string {
+	names := make([]string, len(winAnimationStyles))
+	for i, s := range winAnimationStyles {
+		names[i] = s.name
+	}
+	return names
+}
+
+// winAnimationForName returns the named win animation, or the first (the cascade) if the name is unknown
+func winAnimationForName(name string) winAnimationStyle {
+	for _, s := range winAnimationStyles {
+		if s.name == name {
+			return s
+		}
+	}
+	return winAnimationStyles[0]
+}
+
+// celebration holds the cards of a won game for a win animation to draw.
+// The cards leave the foundations in order, kings first, and the piles show the cards not yet played.
+type celebration struct {
+	layer *fyne.Container
+	size  fyne.Size
+	card  fyne.Size // the size of cards on the table
+
+	cards  []*Card
+	starts []fyne.Position // the position of the foundation each card leaves from
+	pileOf []int
+
+	piles     []*canvas.Image
+	blanks    []*canvas.Rectangle // hide the foundations of the table once a pile is empty
+	remaining [][]*Card           // the cards still on each foundation
+}
+
+func newCelebration(foundations []*Stack, layout *tableLayout, size fyne.Size) *celebration {
+	c := &celebration{layer: container.NewWithoutLayout(), size: size, card: layout.cardSize}
+	stackPos := layout.foundationPos
+	c.layer.Resize(size)
+
+	for i, b := range foundations {
+		img := newCardPos(b.Top())
+		if b.Top() == nil {
+			img = newCardSpace()
+		}
+		layout.placeCard(img, stackPos(i))
+		blank := canvas.NewRectangle(theme.Color(colorNameFelt))
+		blank.Resize(c.card)
+		blank.Move(stackPos(i))
+		blank.Hide()
+
+		c.piles = append(c.piles, img)
+		c.blanks = append(c.blanks, blank)
+		c.remaining = append(c.remaining, append([]*Card{}, b.Cards...))
+		c.layer.Add(blank)
+		c.layer.Add(img)
+	}
+
+	for value := ValueKing; value > 0; value-- {
+		for i, b := range foundations {
+			if value > len(b.Cards) {
+				continue
+			}
+			c.cards = append(c.cards, b.Cards[value-1])
+			c.starts = append(c.starts, stackPos(i))
+			c.pileOf = append(c.pileOf, i)
+		}
+	}
+	return c
+}
+
+// launch takes card i off its foundation, returning a new image of it at the foundation position
+func (c *celebration) launch(i int) *canvas.Image {
+	pile := c.pileOf[i]
+	cards := c.remaining[pile]
+	if len(cards) > 0 {
+		cards = cards[:len(cards)-1]
+		c.remaining[pile] = cards
+	}
+	if len(cards) > 0 {
+		c.piles[pile].Resource = cards[len(cards)-1].Face()
+	} else {
+		c.piles[pile].Resource = faces.ForSpace()
+		c.blanks[pile].Show()
+	}
+	c.piles[pile].Refresh()
+
+	img := canvas.NewImageFromResource(c.cards[i].Face())
+	img.Resize(c.card)
+	img.Move(c.starts[i])
+	c.layer.Add(img)
+	return img
+}
+
+// add places a new object in the layer, above those already there
+func (c *celebration) add(o fyne.CanvasObject) {
+	c.layer.Add(o)
+}
+
+// flyingCard is a card moving under gravity in the cascade
+type flyingCard struct {
+	img      *canvas.Image
+	pos      fyne.Position
+	vx, vy   float32
+	trailed  time.Duration
+	finished bool
+}
+
+// cascade is the classic effect of cards bouncing across the table, leaving a trail behind them
+type cascade struct {
+	c        *celebration
+	flying   []*flyingCard
+	last     time.Duration
+	launched int
+	trails   int
+}
+
+const cascadeInterval = time.Millisecond * 150
+
+func newCascade(c *celebration) winAnimation {
+	return &cascade{c: c}
+}
+
+func (a *cascade) step(elapsed time.Duration) bool {
+	dt := float32((elapsed - a.last).Seconds())
+	a.last = elapsed
+	unit := a.c.card.Width
+
+	for a.launched < len(a.c.cards) && elapsed >= time.Duration(a.launched)*cascadeInterval {
+		start := a.c.starts[a.launched]
+		card := &flyingCard{img: a.c.launch(a.launched), pos: start,
+			vx: unit * (0.8 + rand.Float32()*1.8), vy: -unit * rand.Float32() * 2}
+		if start.X+a.c.card.Width/2 > a.c.size.Width/2 {
+			card.vx = -card.vx // fly towards the side of the table with more room
+		}
+		a.flying = append(a.flying, card)
+		a.launched++
+	}
+
+	floor := a.c.size.Height - a.c.card.Height
+	active := false
+	for _, f := range a.flying {
+		if f.finished {
+			continue
+		}
+
+		f.vy += unit * 16 * dt
+		f.pos = f.pos.Add(fyne.NewPos(f.vx*dt, f.vy*dt))
+		if f.pos.Y > floor {
+			f.pos.Y = floor
+			f.vy = -f.vy * 0.75
+		}
+		if f.pos.X < -a.c.card.Width || f.pos.X > a.c.size.Width {
+			f.finished = true
+			f.img.Hide()
+			continue
+		}
+
+		active = true
+		if elapsed-f.trailed > time.Millisecond*30 && a.trails < maxTrails {
+			trail := canvas.NewImageFromResource(f.img.Resource)
+			trail.Resize(a.c.card)
+			trail.Move(f.pos)
+			a.c.add(trail)
+			a.trails++
+			f.trailed = elapsed
+		}
+		f.img.Move(f.pos) // also repaints the trails that were added
+	}
+
+	return active || a.launched < len(a.c.cards)
+}
+
+// particle is a spark from a firework
+type particle struct {
+	dot    *canvas.Circle
+	pos    fyne.Position
+	vx, vy float32
+	born   time.Duration
+}
+
+// rocket is a card that flies up from the bottom of the table and bursts into sparks
+type rocket struct {
+	img    *canvas.Image
+	pos    fyne.Position
+	vy     float32
+	peak   float32
+	colour color.NRGBA
+	burst  bool
+}
+
+// fireworks launches the cards as rockets that burst in the colour of their suit
+type fireworks struct {
+	c         *celebration
+	rockets   []*rocket
+	particles []*particle
+	last      time.Duration
+	launched  int
+}
+
+const (
+	fireworkInterval = time.Millisecond * 160
+	sparkLife        = time.Millisecond * 1200
+	sparksPerRocket  = 18
+)
+
+func newFireworks(c *celebration) winAnimation {
+	return &fireworks{c: c}
+}
+
+func (a *fireworks) step(elapsed time.Duration) bool {
+	dt := float32((elapsed - a.last).Seconds())
+	a.last = elapsed
+	unit := a.c.card.Width
+
+	for a.launched < len(a.c.cards) && elapsed >= time.Duration(a.launched)*fireworkInterval {
+		img := a.c.launch(a.launched)
+		small := fyne.NewSize(a.c.card.Width/3, a.c.card.Height/3)
+		img.Resize(small)
+		pos := fyne.NewPos(rand.Float32()*(a.c.size.Width-small.Width), a.c.size.Height)
+		img.Move(pos)
+
+		colour := color.NRGBA{R: 0xff, G: 0xd7, B: 0x30, A: 0xff}
+		if a.c.cards[a.launched].Color() == SuitColorRed {
+			colour = color.NRGBA{R: 0xff, G: 0x40, B: 0x40, A: 0xff}
+		}
+		a.rockets = append(a.rockets, &rocket{img: img, pos: pos, vy: -unit * 6, colour: colour,
+			peak: a.c.size.Height * (0.15 + rand.Float32()*0.35)})
+		a.launched++
+	}
+
+	active := a.launched < len(a.c.cards)
+	for _, r := range a.rockets {
+		if r.burst {
+			continue
+		}
+
+		active = true
+		r.pos.Y += r.vy * dt
+		r.img.Move(r.pos)
+		if r.pos.Y > r.peak {
+			continue
+		}
+
+		r.burst = true
+		r.img.Hide()
+		for i := 0; i < sparksPerRocket; i++ {
+			angle := 2 * math.Pi * float64(i) / sparksPerRocket
+			speed := unit * (1.5 + rand.Float32())
+			dot := canvas.NewCircle(r.colour)
+			dot.Resize(fyne.NewSize(unit/12, unit/12))
+			dot.Move(r.pos)
+			a.c.add(dot)
+			a.particles = append(a.particles, &particle{dot: dot, pos: r.pos, born: elapsed,
+				vx: speed * float32(math.Cos(angle)), vy: speed * float32(math.Sin(angle))})
+		}
+	}
+
+	for _, p := range a.particles {
+		age := elapsed - p.born
+		if age > sparkLife {
+			p.dot.Hide()
+			continue
+		}
+
+		active = true
+		p.vy += unit * 2 * dt
+		p.pos = p.pos.Add(fyne.NewPos(p.vx*dt, p.vy*dt))
+		p.dot.Move(p.pos)
+		fill := p.dot.FillColor.(color.NRGBA)
+		fill.A = uint8(255 * (1 - float64(age)/float64(sparkLife)))
+		p.dot.FillColor = fill
+		p.dot.Refresh()
+	}
+
+	return active
+}
+
+// fanOut deals the cards into an arc across the table, like a hand of cards being spread out
+type fanOut struct {
+	c        *celebration
+	images   []*canvas.Image
+	targets  []fyne.Position
+	launched int
+}
+
+const (
+	fanDelay = time.Millisecond * 60
+	fanMove  = time.Millisecond * 600
+	fanHold  = time.Second * 2
+)
+
+func newFanOut(c *celebration) winAnimation {
+	a := &fanOut{c: c}
+
+	count := len(c.cards)
+	centre := fyne.NewPos(c.size.Width/2, c.size.Height*0.95)
+	radius := float64(fyne.Min(c.size.Width/2, c.size.Height*0.8)) - float64(c.card.Height)/2
+	for i := 0; i < count; i++ {
+		angle := (float64(i)/float64(fyne.Max(float32(count-1), 1)) - 0.5) * math.Pi * 0.8
+		x := centre.X + float32(radius*math.Sin(angle)) - c.card.Width/2
+		y := centre.Y - float32(radius*math.Cos(angle)) - c.card.Height
+		a.targets = append(a.targets, fyne.NewPos(x, y))
+	}
+	return a
+}
+
+func (a *fanOut) step(elapsed time.Duration) bool {
+	for a.launched < len(a.c.cards) && elapsed >= time.Duration(a.launched)*fanDelay {
+		a.images = append(a.images, a.c.launch(a.launched))
+		a.launched++
+	}
+
+	for i, img := range a.images {
+		progress := float32(elapsed-time.Duration(i)*fanDelay) / float32(fanMove)
+		if progress > 1 {
+			progress = 1
+		}
+		progress = 1 - (1-progress)*(1-progress) // ease out
+		start, end := a.c.starts[i], a.targets[i]
+		img.Move(fyne.NewPos(start.X+(end.X-start.X)*progress, start.Y+(end.Y-start.Y)*progress))
+	}
+
+	return elapsed < time.Duration(len(a.c.cards))*fanDelay+fanMove+fanHold
+}
+
+// celebrate plays the chosen win animation over the table.
+// The onFinished function is called when it ends or is skipped, but not if it is cancelled by a new game.
+func (t *Table) celebrate(onFinished func()) {
+	var c fyne.Canvas
+	pos := fyne.NewPos(0, 0)
+	if app := fyne.CurrentApp(); app != nil {
+		c = app.Driver().CanvasForObject(t)
+		pos = app.Driver().AbsolutePositionForObject(t)
+	}
+	style := winAnimationForName(t.winAnimation)
+	party := newCelebration(t.game.Foundations, t.layout, t.Size())
+	anim := style.start(party)
+
+	skip := newTapLayer(t.skipCelebration)
+	skip.Resize(t.Size())
+	overlay := container.NewWithoutLayout(party.layer, skip)
+	overlay.Resize(t.Size())
+	overlay.Move(pos)
+	if c != nil {
+		c.Overlays().Add(overlay)
+	}
+
+	t.animations.start(animCelebration, maxCelebration, fyne.AnimationLinear, func(progress float32) {
+		if !anim.step(time.Duration(float64(progress) * float64(maxCelebration))) {
+			t.skipCelebration()
+		}
+	}, func(completed bool) {
+		if c != nil {
+			c.Overlays().Remove(overlay)
+		}
+		if completed && onFinished != nil {
+			onFinished()
+		}
+	})
+}
+
+// skipCelebration ends any win animation that is playing
+func (t *Table) skipCelebration() {
+	t.animations.finish(animCelebration)
+}
+
+// tapLayer is a transparent widget that calls a function when tapped, used to skip an animation
+type tapLayer struct {
+	widget.BaseWidget
+	onTapped func()
+}
+
+func newTapLayer(onTapped func()) *tapLayer {
+	t := &tapLayer{onTapped: onTapped}
+	t.ExtendBaseWidget(t)
+	return t
+}
+
+func (t *tapLayer) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(canvas.NewRectangle(color.Transparent))
+}
+
+func (t *tapLayer) Tapped(*fyne.PointEvent) {
+	t.onTapped()
+}
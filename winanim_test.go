@@ -0,0 +1,85 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// newWonTable returns a laid out table with every card on the foundations
+func newWonTable() *Table {
+	g := newEmptyGame()
+	for i, b := range g.Foundations {
+		for v := 1; v <= ValueKing; v++ {
+			b.Push(&Card{Value: v, Suit: Suit(i), FaceUp: true})
+		}
+	}
+
+	table := NewTable(g)
+	table.Resize(fyne.NewSize(700, 500))
+	test.WidgetRenderer(table).Layout(table.Size())
+	return table
+}
+
+func TestWinAnimationForName(t *testing.T) {
+	assert.Equal(t, "Fireworks", winAnimationForName("Fireworks").name)
+	assert.Equal(t, "Cascade", winAnimationForName("").name)
+	assert.Equal(t, "Cascade", winAnimationForName("Unknown").name)
+}
+
+func TestCelebration_Launch(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	table := newWonTable()
+
+	c := newCelebration(table.game.Foundations, table.layout, table.Size())
+	assert.Equal(t, 52, len(c.cards))
+	assert.Equal(t, ValueKing, c.cards[0].Value)
+	assert.Equal(t, 1, c.cards[51].Value)
+
+	c.launch(0)
+	assert.Equal(t, table.game.Foundations[0].Cards[11].Face(), c.piles[0].Resource)
+	assert.False(t, c.blanks[0].Visible())
+	assert.Equal(t, ValueKing, len(table.game.Foundations[0].Cards))
+}
+
+func TestWinAnimations_Finish(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	table := newWonTable()
+
+	for _, style := range winAnimationStyles {
+		c := newCelebration(table.game.Foundations, table.layout, table.Size())
+		anim := style.start(c)
+
+		elapsed := time.Duration(0)
+		for anim.step(elapsed) && elapsed < maxCelebration {
+			elapsed += time.Millisecond * 16
+		}
+		assert.Less(t, elapsed, maxCelebration, style.name)
+		for i, blank := range c.blanks {
+			assert.Empty(t, c.remaining[i], style.name)
+			assert.True(t, blank.Visible(), style.name)
+		}
+	}
+}
+
+func TestTable_SkipCelebration(t *testing.T) {
+	a := test.NewApp()
+	defer a.Quit()
+	table := newWonTable()
+
+	finished := 0
+	table.celebrate(func() {
+		finished++
+	})
+	table.skipCelebration()
+	assert.Equal(t, 1, finished)
+	assert.False(t, table.animations.isRunning(animCelebration))
+
+	table.skipCelebration()
+	assert.Equal(t, 1, finished)
+}